@@ -3,4 +3,49 @@ package v1alpha1
 const (
 	SchemaRegistryLabelName = "client.sroperator.io/instance"
 	SchemaVersionLatest     = "latest"
+
+	PausedAnnotation         = "client.sroperator.io/paused"
+	ForceReconcileAnnotation = "client.sroperator.io/force-reconcile"
+	ForceDeleteAnnotation    = "client.sroperator.io/force-delete"
+	ApprovedByAnnotation     = "client.sroperator.io/approved-by"
+
+	BackupLabelName           = "client.sroperator.io/backup"
+	ArchiveLabelName          = "client.sroperator.io/archive"
+	ArchiveStagingLabelName   = "client.sroperator.io/archive-staging"
+	ArchiveChunkAnnotation    = "client.sroperator.io/chunk"
+	ArchiveChecksumAnnotation = "client.sroperator.io/checksum"
+	ArchiveDataKey            = "archive"
+
+	RollbackNameProperty    = "client.sroperator.io/rollback"
+	RollbackVersionProperty = "client.sroperator.io/rollback-version"
+	SchemaHistoryLimit      = 10
+
+	RoleLabelName = "client.sroperator.io/role"
+	RoleRead      = "read"
+	RolePrimary   = "primary"
+
+	ConfigChecksumAnnotation = "client.sroperator.io/config-checksum"
+	ConfigEnvPrefix          = "SCHEMA_REGISTRY_"
+
+	// Only the Secrets and ConfigMaps with the watch label are cached and watched by the operator
+	WatchLabelName  = "client.sroperator.io/watch"
+	WatchLabelValue = "true"
 )
+
+// ManagedConfig are the schema registry properties rendered by the operator, which can not be overridden by config
+var ManagedConfig = []string{
+	"host.name",
+	"listeners",
+	"inter.instance.protocol",
+	"kafkastore.bootstrap.servers",
+	"kafkastore.group.id",
+	"group.id",
+	"master.eligibility",
+	"leader.eligibility",
+	"kafkastore.security.protocol",
+	"kafkastore.sasl.mechanism",
+	"kafkastore.sasl.jaas.config",
+	"debug",
+	"schema.compatibility.level",
+	"mode.mutability",
+}
@@ -6,12 +6,42 @@ import (
 )
 
 var (
-	ErrInstanceLabelNotFound    = errors.New("instance label not found")
-	ErrInstanceNotFound         = errors.New("schema registry instance not found")
-	ErrIncompatibleSchema       = errors.New("incompatible schema")
-	ErrInvalidSchemaOrType      = errors.New("invalid schema or schema type")
-	ErrFailedToSoftDeleteSchema = errors.New("failed to soft delete schema")
-	ErrFailedToHardDeleteSchema = errors.New("failed to hard delete schema")
+	ErrInstanceLabelNotFound     = errors.New("instance label not found")
+	ErrInstanceNotFound          = errors.New("schema registry instance not found")
+	ErrIncompatibleSchema        = errors.New("incompatible schema")
+	ErrInvalidSchemaOrType       = errors.New("invalid schema or schema type")
+	ErrFailedToSoftDeleteSchema  = errors.New("failed to soft delete schema")
+	ErrFailedToHardDeleteSchema  = errors.New("failed to hard delete schema")
+	ErrFailedToGetGlobalConfig   = errors.New("failed to get global config")
+	ErrFailedToSetGlobalConfig   = errors.New("failed to set global config")
+	ErrFailedToGetGlobalMode     = errors.New("failed to get global mode")
+	ErrFailedToSetGlobalMode     = errors.New("failed to set global mode")
+	ErrFailedToListSubjects      = errors.New("failed to list subjects")
+	ErrFailedToListVersions      = errors.New("failed to list versions")
+	ErrFailedToGetSchema         = errors.New("failed to get schema")
+	ErrFailedToGetSubjectConfig  = errors.New("failed to get subject config")
+	ErrFailedToGetSubjectMode    = errors.New("failed to get subject mode")
+	ErrInvalidSchedule           = errors.New("invalid schedule")
+	ErrFailedToSetSubjectMode    = errors.New("failed to set subject mode")
+	ErrFailedToSetSubjectConfig  = errors.New("failed to set subject config")
+	ErrFailedToImportSchema      = errors.New("failed to import schema")
+	ErrArchiveNotFound           = errors.New("archive not found")
+	ErrArchiveChecksumMismatch   = errors.New("archive checksum mismatch")
+	ErrFailedToReadArchive       = errors.New("failed to read archive")
+	ErrInstanceNotReady          = errors.New("schema registry instance not ready")
+	ErrInstancePaused            = errors.New("schema registry instance paused")
+	ErrInvalidSubjectPattern     = errors.New("invalid subject pattern")
+	ErrFailedToLookUpSchema      = errors.New("failed to look up schema")
+	ErrFailedToTestCompatibility = errors.New("failed to test compatibility")
+	ErrVersionNotFound           = errors.New("version not found")
+	ErrFailedToGetReferencedBy   = errors.New("failed to get referencing schemas")
+	ErrInvalidTargetSelector     = errors.New("invalid target selector")
+	ErrFailedToGetClusterID      = errors.New("failed to get cluster id")
+	ErrFailedToGetServerVersion  = errors.New("failed to get server version")
+	ErrManagedConfig             = errors.New("config is managed by the operator")
+	ErrConflictingConfig         = errors.New("config is also set in additionalConfig")
+	ErrSecretKeyNotFound         = errors.New("secret key not found")
+	ErrInvalidCertificate        = errors.New("invalid certificate")
 )
 
 func NewIncompatibleSchemaError(message string) error {
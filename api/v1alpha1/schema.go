@@ -2,29 +2,319 @@ package v1alpha1
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/steffen-karlsson/schema-registry-operator/pkg/hash"
+	"github.com/steffen-karlsson/schema-registry-operator/pkg/srclient"
 )
 
 // IsSubjectUnique checks if the subject of the schema is unique in the schema registry instance
 func (s *Schema) IsSubjectUnique(
 	ctx context.Context,
 	r client.Reader,
-	schemaRegistryInstanceName string,
+	schemaRegistry *SchemaRegistry,
 ) (bool, error) {
 	potentialMatchingSchemas := &SchemaList{}
-	if err := r.List(ctx, potentialMatchingSchemas,
-		client.InNamespace(s.Namespace),
-		client.MatchingLabels{SchemaRegistryLabelName: schemaRegistryInstanceName}); err != nil {
-
+	if err := r.List(ctx, potentialMatchingSchemas, client.InNamespace(s.Namespace)); err != nil {
 		return false, err
 	}
 
 	for _, potentialMatchingSchema := range potentialMatchingSchemas.Items {
-		if potentialMatchingSchema.GetSubject() == s.GetSubject() {
+		if potentialMatchingSchema.UID == s.UID || potentialMatchingSchema.GetSubject() != s.GetSubject() {
+			continue
+		}
+
+		targeting, err := potentialMatchingSchema.IsTargeting(schemaRegistry)
+		if err != nil {
+			return false, err
+		}
+
+		if targeting {
 			return false, nil
 		}
 	}
 
 	return true, nil
 }
+
+// IsTargeting checks if the schema is registered to the schema registry instance,
+// either by the instance label or by the targets of the schema
+func (s *Schema) IsTargeting(schemaRegistry *SchemaRegistry) (bool, error) {
+	if s.Namespace != schemaRegistry.Namespace {
+		return false, nil
+	}
+
+	if s.Labels[SchemaRegistryLabelName] == schemaRegistry.Name {
+		return true, nil
+	}
+
+	if s.Spec.Targets == nil {
+		return false, nil
+	}
+
+	if slices.Contains(s.Spec.Targets.Names, schemaRegistry.Name) {
+		return true, nil
+	}
+
+	if s.Spec.Targets.Selector == nil {
+		return false, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(s.Spec.Targets.Selector)
+	if err != nil {
+		return false, fmt.Errorf("%w: %w", ErrInvalidTargetSelector, err)
+	}
+
+	return selector.Matches(labels.Set(schemaRegistry.Labels)), nil
+}
+
+// ResolveTargets returns the schema registry instances the schema is registered to, starting with the instance
+// of the instance label followed by the targets ordered by name, and the names of the instances which are not found
+func (s *Schema) ResolveTargets(
+	ctx context.Context,
+	r client.Reader,
+) ([]SchemaRegistry, []string, error) {
+	schemaRegistries := &SchemaRegistryList{}
+	if err := r.List(ctx, schemaRegistries, client.InNamespace(s.Namespace)); err != nil {
+		return nil, nil, err
+	}
+
+	var targets []SchemaRegistry
+	found := make(map[string]bool, len(schemaRegistries.Items))
+	for _, schemaRegistry := range schemaRegistries.Items {
+		targeting, err := s.IsTargeting(&schemaRegistry)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if targeting {
+			targets = append(targets, schemaRegistry)
+			found[schemaRegistry.Name] = true
+		}
+	}
+
+	instance := s.Labels[SchemaRegistryLabelName]
+	sort.SliceStable(targets, func(i, j int) bool {
+		if targets[i].Name == instance || targets[j].Name == instance {
+			return targets[i].Name == instance
+		}
+		return targets[i].Name < targets[j].Name
+	})
+
+	var names, missing []string
+	if instance != "" {
+		names = append(names, instance)
+	}
+	if s.Spec.Targets != nil {
+		names = append(names, s.Spec.Targets.Names...)
+	}
+
+	for _, name := range names {
+		if !found[name] && !slices.Contains(missing, name) {
+			missing = append(missing, name)
+		}
+	}
+
+	return targets, missing, nil
+}
+
+// RecordRegistry records that the subject is registered in the schema registry instance
+func (s *Schema) RecordRegistry(name string) {
+	if !slices.Contains(s.Status.Registries, name) {
+		s.Status.Registries = append(s.Status.Registries, name)
+		slices.Sort(s.Status.Registries)
+	}
+}
+
+// ForgetRegistry records that the subject is no longer registered in the schema registry instance
+func (s *Schema) ForgetRegistry(name string) {
+	s.Status.Registries = slices.DeleteFunc(s.Status.Registries, func(registry string) bool {
+		return registry == name
+	})
+}
+
+// UntargetedRegistries returns the schema registry instances the subject is registered in which are no longer
+// targeted by the schema, and the names of those instances which no longer exist
+func (s *Schema) UntargetedRegistries(
+	ctx context.Context,
+	r client.Reader,
+	targets []SchemaRegistry,
+) ([]SchemaRegistry, []string, error) {
+	var untargeted []SchemaRegistry
+	var gone []string
+	for _, name := range s.Status.Registries {
+		if slices.ContainsFunc(targets, func(target SchemaRegistry) bool { return target.Name == name }) {
+			continue
+		}
+
+		schemaRegistry := &SchemaRegistry{}
+		err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: s.Namespace}, schemaRegistry)
+		switch {
+		case apierrors.IsNotFound(err):
+			gone = append(gone, name)
+		case err != nil:
+			return nil, nil, err
+		default:
+			untargeted = append(untargeted, *schemaRegistry)
+		}
+	}
+
+	return untargeted, gone, nil
+}
+
+// GetReferences returns the references of the schema in the format of the schema registry
+func (s *Schema) GetReferences() *[]srclient.SchemaReference {
+	if len(s.Spec.References) == 0 {
+		return nil
+	}
+
+	references := make([]srclient.SchemaReference, 0, len(s.Spec.References))
+	for _, reference := range s.Spec.References {
+		references = append(references, srclient.SchemaReference{
+			Name:    ptr.To(reference.Name),
+			Subject: ptr.To(reference.Subject),
+			Version: ptr.To(reference.Version),
+		})
+	}
+
+	return &references
+}
+
+// GetMetadata returns the metadata of the schema in the format of the schema registry
+func (s *Schema) GetMetadata() *srclient.Metadata {
+	if s.Spec.Metadata == nil {
+		return nil
+	}
+
+	metadata := &srclient.Metadata{}
+	if len(s.Spec.Metadata.Properties) > 0 {
+		metadata.Properties = &s.Spec.Metadata.Properties
+	}
+
+	if len(s.Spec.Metadata.Tags) > 0 {
+		metadata.Tags = &s.Spec.Metadata.Tags
+	}
+
+	if len(s.Spec.Metadata.Sensitive) > 0 {
+		metadata.Sensitive = &s.Spec.Metadata.Sensitive
+	}
+
+	return metadata
+}
+
+func (s *Schema) registerSchemaRequest() srclient.RegisterSchemaRequest {
+	return srclient.RegisterSchemaRequest{
+		Schema:     &s.Spec.Content,
+		SchemaType: &s.Spec.Type,
+		References: s.GetReferences(),
+		Metadata:   s.GetMetadata(),
+	}
+}
+
+// SetRegisteredSchema sets the content, references and metadata of the schema to the ones registered
+// in the schema registry, so deploying the schema does not register a new version
+func (s *Schema) SetRegisteredSchema(registered *srclient.Schema) {
+	s.Spec.Content = ptr.Deref(registered.Schema, "")
+
+	s.Spec.References = nil
+	for _, reference := range ptr.Deref(registered.References, nil) {
+		s.Spec.References = append(s.Spec.References, SchemaReference{
+			Name:    ptr.Deref(reference.Name, ""),
+			Subject: ptr.Deref(reference.Subject, ""),
+			Version: ptr.Deref(reference.Version, 0),
+		})
+	}
+
+	s.Spec.Metadata = nil
+	if registered.Metadata != nil {
+		s.Spec.Metadata = &SchemaMetadata{
+			Properties: ptr.Deref(registered.Metadata.Properties, nil),
+			Tags:       ptr.Deref(registered.Metadata.Tags, nil),
+			Sensitive:  ptr.Deref(registered.Metadata.Sensitive, nil),
+		}
+	}
+}
+
+// RecordRegisteredSchema records the latest version and schema ID registered in the schema registry in the status,
+// and appends them to the history when they differ from the latest entry or were registered by a rollback
+func (s *Schema) RecordRegisteredSchema(registry string, registered *srclient.Schema, rollback *SchemaRollbackRecord) error {
+	version := ptr.Deref(registered.Version, 0)
+	id := ptr.Deref(registered.Id, 0)
+	s.Status.LatestVersion = int(version)
+	s.Status.SchemaID = id
+
+	if history := s.Status.History; rollback == nil && len(history) > 0 && history[len(history)-1].Registry == registry &&
+		history[len(history)-1].Version == version && history[len(history)-1].ID == id {
+		return nil
+	}
+
+	contentHash, err := hash.Hash(ptr.Deref(registered.Schema, ""))
+	if err != nil {
+		return err
+	}
+
+	s.RecordHistory(SchemaHistoryEntry{
+		Version:      version,
+		ID:           id,
+		Registry:     registry,
+		ContentHash:  fmt.Sprintf("%08x", contentHash),
+		RegisteredAt: metav1.Now(),
+		Generation:   s.Generation,
+		Rollback:     rollback,
+	})
+
+	return nil
+}
+
+// ExpiredVersions returns the versions in the given registry which are neither one of the last versions nor newer
+// than the maximum age of the retention policy. The age of a version is taken from the history recorded for the
+// registry, as the version numbers differ between registries, and a version which is no longer in the history is
+// at least as old as the closest newer version in it. The latest version never expires.
+func (s *Schema) ExpiredVersions(registry string, versions []int32, now time.Time) []int32 {
+	retention := s.Spec.Retention
+	if retention == nil || (retention.KeepLast == nil && retention.MaxAge == nil) {
+		return nil
+	}
+
+	registeredAt := make(map[int32]time.Time, len(s.Status.History))
+	for _, entry := range s.Status.History {
+		if entry.Registry != registry {
+			continue
+		}
+		registeredAt[entry.Version] = entry.RegisteredAt.Time
+	}
+
+	var expired []int32
+	var knownAt time.Time
+	for i := len(versions) - 1; i >= 0; i-- {
+		if at, ok := registeredAt[versions[i]]; ok {
+			knownAt = at
+		}
+
+		if i == len(versions)-1 {
+			continue
+		}
+
+		keep := retention.KeepLast != nil && len(versions)-i <= int(*retention.KeepLast)
+		if retention.MaxAge != nil && (knownAt.IsZero() || now.Sub(knownAt) < retention.MaxAge.Duration) {
+			keep = true
+		}
+
+		if !keep {
+			expired = append(expired, versions[i])
+		}
+	}
+	slices.Reverse(expired)
+
+	return expired
+}
@@ -0,0 +1,104 @@
+package v1alpha1
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestExpiredVersions(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	registeredAt := func(registry string, ages map[int32]time.Duration) []SchemaHistoryEntry {
+		var history []SchemaHistoryEntry
+		for version, age := range ages {
+			history = append(history, SchemaHistoryEntry{
+				Version:      version,
+				Registry:     registry,
+				RegisteredAt: metav1.NewTime(now.Add(-age)),
+			})
+		}
+
+		return history
+	}
+	day := &metav1.Duration{Duration: 24 * time.Hour}
+
+	tests := []struct {
+		name      string
+		retention *SchemaRetention
+		history   []SchemaHistoryEntry
+		versions  []int32
+		expired   []int32
+	}{
+		{
+			name:     "no retention",
+			versions: []int32{1, 2, 3},
+		},
+		{
+			name:      "keep last",
+			retention: &SchemaRetention{KeepLast: ptr.To(int32(2))},
+			versions:  []int32{1, 2, 3, 4, 5},
+			expired:   []int32{1, 2, 3},
+		},
+		{
+			name:      "keep last more than registered",
+			retention: &SchemaRetention{KeepLast: ptr.To(int32(5))},
+			versions:  []int32{1, 2, 3},
+		},
+		{
+			name:      "max age",
+			retention: &SchemaRetention{MaxAge: day},
+			history:   registeredAt("sr", map[int32]time.Duration{1: 72 * time.Hour, 2: 48 * time.Hour, 3: 2 * time.Hour}),
+			versions:  []int32{1, 2, 3, 4},
+			expired:   []int32{1, 2},
+		},
+		{
+			name:      "keep last and max age",
+			retention: &SchemaRetention{KeepLast: ptr.To(int32(3)), MaxAge: day},
+			history:   registeredAt("sr", map[int32]time.Duration{1: 72 * time.Hour, 2: 48 * time.Hour, 3: 2 * time.Hour}),
+			versions:  []int32{1, 2, 3, 4},
+			expired:   []int32{1},
+		},
+		{
+			name:      "max age of versions older than the history",
+			retention: &SchemaRetention{MaxAge: day},
+			history:   registeredAt("sr", map[int32]time.Duration{3: 48 * time.Hour}),
+			versions:  []int32{1, 2, 3, 4},
+			expired:   []int32{1, 2, 3},
+		},
+		{
+			name:      "max age of versions older than a recent history",
+			retention: &SchemaRetention{MaxAge: day},
+			history:   registeredAt("sr", map[int32]time.Duration{3: 2 * time.Hour}),
+			versions:  []int32{1, 2, 3, 4},
+		},
+		{
+			name:      "max age without history",
+			retention: &SchemaRetention{MaxAge: day},
+			versions:  []int32{1, 2, 3},
+		},
+		{
+			name:      "max age of the history of another registry",
+			retention: &SchemaRetention{MaxAge: day},
+			history:   registeredAt("sr-other", map[int32]time.Duration{1: 72 * time.Hour, 2: 48 * time.Hour}),
+			versions:  []int32{1, 2, 3},
+		},
+		{
+			name:      "latest version",
+			retention: &SchemaRetention{MaxAge: day},
+			history:   registeredAt("sr", map[int32]time.Duration{1: 72 * time.Hour}),
+			versions:  []int32{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := &Schema{Spec: SchemaSpec{Retention: tt.retention}, Status: SchemaStatus{History: tt.history}}
+			if expired := schema.ExpiredVersions("sr", tt.versions, now); !slices.Equal(expired, tt.expired) {
+				t.Errorf("ExpiredVersions() = %v, want %v", expired, tt.expired)
+			}
+		})
+	}
+}
@@ -32,6 +32,11 @@ import (
 	"github.com/steffen-karlsson/schema-registry-operator/pkg/hash"
 )
 
+const (
+	SchemaConditionDeletionBlocked = "DeletionBlocked"
+	SchemaReasonReferenced         = "ReferencedBySubjects"
+)
+
 // SchemaSpec defines the desired state of Schema
 type SchemaSpec struct {
 	// +kubebuilder:validation:Optional
@@ -64,12 +69,87 @@ type SchemaSpec struct {
 	// Used to define if the schema should be normalized, default is false
 	Normalize bool `json:"normalize" default:"false"`
 
+	// +kubebuilder:validation:Optional
+	// Used to define the references to schemas registered under other subjects
+	References []SchemaReference `json:"references,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define the user-defined metadata of the schema
+	Metadata *SchemaMetadata `json:"metadata,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define the schema registry instances the schema is registered to, besides the instance of the instance label
+	Targets *SchemaTargets `json:"targets,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define which versions of the subject are kept, by default all versions are kept
+	Retention *SchemaRetention `json:"retention,omitempty"`
+
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	// Used to define if changes are only planned and recorded in the status instead of applied, default is false
+	DryRun bool `json:"dryRun,omitempty" default:"false"`
+
 	// +kubebuilder:default:={}
 	// +kubebuilder:validation:Optional
 	// Used to define the schema registry configuration
 	SchemaRegistryConfig SchemaRegistryConfig `json:"schemaRegistryConfig"`
 }
 
+// SchemaReference defines a reference to a schema registered under another subject
+type SchemaReference struct {
+	// Used to define the name of the reference, such as the fully qualified name of an Avro record
+	Name string `json:"name"`
+
+	// Used to define the subject of the referenced schema
+	Subject string `json:"subject"`
+
+	// Used to define the version of the referenced schema
+	Version int32 `json:"version"`
+}
+
+// SchemaMetadata defines the user-defined metadata of a schema
+type SchemaMetadata struct {
+	// +kubebuilder:validation:Optional
+	// Used to define the metadata properties
+	Properties map[string]string `json:"properties,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define the metadata tags, per field path
+	Tags map[string][]string `json:"tags,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define the names of the sensitive metadata properties
+	Sensitive []string `json:"sensitive,omitempty"`
+}
+
+// SchemaTargets defines the schema registry instances a schema is registered to, in the same namespace
+type SchemaTargets struct {
+	// +kubebuilder:validation:Optional
+	// Used to define the names of the schema registry instances
+	Names []string `json:"names,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define the label selector of the schema registry instances
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// SchemaRetention defines which versions of a subject are kept. A version is deleted when it is neither
+// one of the last versions nor newer than the maximum age. The latest version and versions referenced
+// by other subjects are never deleted.
+// +kubebuilder:validation:XValidation:rule="has(self.keepLast) || has(self.maxAge)",message="at least one of keepLast or maxAge must be set"
+type SchemaRetention struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// Used to define the number of most recent versions to keep
+	KeepLast *int32 `json:"keepLast,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define the age of the versions to keep, such as 720h, as observed by the operator when registered.
+	// It only applies to the SchemaRegistry the version history of the Schema is recorded for
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+}
+
 type SchemaRegistryConfig struct {
 	// +kubebuilder:default:=300
 	// +kubebuilder:validation:Optional
@@ -79,9 +159,20 @@ type SchemaRegistryConfig struct {
 
 // SchemaStatus defines the observed state of Schema
 type SchemaStatus struct {
-	// Used to define the latest version of the schema
+	// Used to define the latest version of the schema in the first schema registry instance it is deployed to
 	LatestVersion int `json:"latestVersion"`
 
+	// Used to define the global schema ID of the latest version of the schema in the first schema registry instance
+	// it is deployed to
+	SchemaID int32 `json:"schemaId,omitempty"`
+
+	// Used to define the state of the schema per schema registry instance
+	Targets []SchemaTargetStatus `json:"targets,omitempty"`
+
+	// Used to define the schema registry instances the subject is registered in, the subject is deleted from the
+	// instances which are no longer targeted
+	Registries []string `json:"registries,omitempty"`
+
 	// Used to define the status message of the schema
 	Message string `json:"message,omitempty"`
 
@@ -91,10 +182,88 @@ type SchemaStatus struct {
 	// Used to define if the schema is ready
 	Ready bool `json:"ready"`
 
+	// Used to define the changes planned in dry run mode
+	Plan []string `json:"plan,omitempty"`
+
+	// Used to define if the reconciliation is paused by the paused annotation
+	Paused bool `json:"paused,omitempty"`
+
+	// Used to define the value of the latest handled force-reconcile annotation
+	LastForceReconcile string `json:"lastForceReconcile,omitempty"`
+
+	// Used to define the most recent registered versions of the schema, the oldest entries are dropped first
+	History []SchemaHistoryEntry `json:"history,omitempty"`
+
+	// Used to define the conditions of the schema
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
 	// Used to define the last transition time
 	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
 }
 
+// SchemaTargetStatus defines the observed state of the schema in a single schema registry instance
+type SchemaTargetStatus struct {
+	// Used to define the name of the schema registry instance
+	Name string `json:"name"`
+
+	// Used to define if the schema is deployed to the schema registry instance
+	Ready bool `json:"ready"`
+
+	// Used to define the latest version of the schema in the schema registry instance
+	LatestVersion int32 `json:"latestVersion,omitempty"`
+
+	// Used to define the global schema ID of the latest version in the schema registry instance
+	SchemaID int32 `json:"schemaId,omitempty"`
+
+	// Used to define the error of the latest deployment to the schema registry instance
+	Error string `json:"error,omitempty"`
+}
+
+// SchemaHistoryEntry defines a version of the schema registered by the operator
+type SchemaHistoryEntry struct {
+	// Used to define the version of the schema
+	Version int32 `json:"version"`
+
+	// Used to define the global schema ID of the version
+	ID int32 `json:"id"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define the name of the SchemaRegistry the version was registered in
+	Registry string `json:"registry,omitempty"`
+
+	// Used to define the hash of the content of the version
+	ContentHash string `json:"contentHash"`
+
+	// Used to define when the version was registered, as observed by the operator
+	RegisteredAt metav1.Time `json:"registeredAt"`
+
+	// Used to define the generation of the Schema which registered the version
+	Generation int64 `json:"generation"`
+
+	// Used to define the rollback which registered the version, or deleted the versions newer than it
+	Rollback *SchemaRollbackRecord `json:"rollback,omitempty"`
+}
+
+// SchemaRollbackRecord defines a rollback of the schema
+type SchemaRollbackRecord struct {
+	// Used to define the name of the SchemaRollback
+	Name string `json:"name"`
+
+	// Used to define the latest version before the rollback
+	FromVersion int32 `json:"fromVersion"`
+
+	// Used to define the version rolled back to
+	ToVersion int32 `json:"toVersion"`
+
+	// Used to define the policy of the rollback
+	Policy string `json:"policy"`
+
+	// Used to define the versions deleted by the rollback
+	DeletedVersions []int32 `json:"deletedVersions,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:subresource:status
@@ -102,7 +271,10 @@ type SchemaStatus struct {
 // +kubebuilder:printcolumn:name="Target",type="string",JSONPath=".spec.target",description="The target of the schema"
 // +kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.type",description="The type of the schema"
 // +kubebuilder:printcolumn:name="Version",type="integer",JSONPath=".status.latestVersion",description="The current version of the schema"
+// +kubebuilder:printcolumn:name="Schema ID",type="integer",JSONPath=".status.schemaId",description="The global schema ID of the current version"
 // +kubebuilder:printcolumn:name="Compatibility Level",type="string",JSONPath=".spec.compatibilityLevel",description="The compatibility level of the schema"
+// +kubebuilder:printcolumn:name="Paused",type="boolean",JSONPath=".status.paused",description="If the reconciliation is paused"
+// +kubebuilder:printcolumn:name="Dry Run",type="boolean",JSONPath=".spec.dryRun",description="If changes are only planned",priority=1
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready",description="The readiness of the schema"
 
 // Schema is the Schema for the schemas API
@@ -132,6 +304,14 @@ func (s *Schema) Hash() (uint32, error) {
 	return hash.Hash(s.Spec.Content)
 }
 
+// RecordHistory appends an entry to the history of the schema, keeping at most SchemaHistoryLimit entries
+func (s *Schema) RecordHistory(entry SchemaHistoryEntry) {
+	s.Status.History = append(s.Status.History, entry)
+	if len(s.Status.History) > SchemaHistoryLimit {
+		s.Status.History = s.Status.History[len(s.Status.History)-SchemaHistoryLimit:]
+	}
+}
+
 // UpdateStatus updates the status of the schema
 func (s *Schema) UpdateStatus(ready bool, message string) {
 	s.Status.Ready = ready
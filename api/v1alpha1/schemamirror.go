@@ -0,0 +1,481 @@
+package v1alpha1
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/steffen-karlsson/schema-registry-operator/pkg/archive"
+	"github.com/steffen-karlsson/schema-registry-operator/pkg/srclient"
+)
+
+// subjectVersions holds the versions of a subject in a schema registry, including soft deleted versions
+type subjectVersions struct {
+	all    []int32
+	exists map[int32]bool
+	active map[int32]bool
+}
+
+// NewClients creates the clients for the source and destination schema registries of the mirror.
+// The Secrets holding the credentials and certificates of the endpoints are read with the secrets reader.
+func (m *SchemaMirror) NewClients(
+	ctx context.Context,
+	reader client.Reader,
+	secrets client.Reader,
+) (*srclient.ClientWithResponses, *srclient.ClientWithResponses, error) {
+	source, err := newEndpointClient(ctx, reader, secrets, m.Namespace, m.Spec.Source)
+	if err != nil {
+		return nil, nil, fmt.Errorf("source: %w", err)
+	}
+
+	destination, err := newEndpointClient(ctx, reader, secrets, m.Namespace, m.Spec.Destination)
+	if err != nil {
+		return nil, nil, fmt.Errorf("destination: %w", err)
+	}
+
+	return source, destination, nil
+}
+
+func newEndpointClient(
+	ctx context.Context,
+	reader client.Reader,
+	secrets client.Reader,
+	namespace string,
+	endpoint MirrorEndpoint,
+) (*srclient.ClientWithResponses, error) {
+	url := endpoint.URL
+	if url == "" {
+		schemaRegistry := &SchemaRegistry{}
+		err := reader.Get(ctx, types.NamespacedName{Name: endpoint.Instance, Namespace: namespace}, schemaRegistry)
+		switch {
+		case apierrors.IsNotFound(err):
+			return nil, fmt.Errorf("%w: %s", ErrInstanceNotFound, endpoint.Instance)
+		case err != nil:
+			return nil, err
+		}
+
+		if IsPaused(schemaRegistry.ObjectMeta) {
+			return nil, fmt.Errorf("%w: %s", ErrInstancePaused, endpoint.Instance)
+		}
+
+		if !schemaRegistry.Status.Ready {
+			return nil, fmt.Errorf("%w: %s", ErrInstanceNotReady, endpoint.Instance)
+		}
+
+		url = schemaRegistry.URL()
+	}
+
+	var opts []srclient.ClientOption
+	if endpoint.BasicAuth != nil {
+		username, err := getSecretKey(ctx, secrets, namespace, &endpoint.BasicAuth.Username)
+		if err != nil {
+			return nil, err
+		}
+
+		password, err := getSecretKey(ctx, secrets, namespace, &endpoint.BasicAuth.Password)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, srclient.WithRequestEditorFn(func(_ context.Context, req *http.Request) error {
+			req.SetBasicAuth(string(username), string(password))
+			return nil
+		}))
+	}
+
+	if endpoint.TLS != nil {
+		tlsConfig, err := newTLSConfig(ctx, secrets, namespace, endpoint.TLS)
+		if err != nil {
+			return nil, err
+		}
+
+		transport := &http.Transport{}
+		if defaultTransport, ok := http.DefaultTransport.(*http.Transport); ok {
+			transport = defaultTransport.Clone()
+		}
+		transport.TLSClientConfig = tlsConfig
+
+		opts = append(opts, srclient.WithHTTPClient(&http.Client{Transport: transport}))
+	}
+
+	return srclient.NewClientWithResponses(url, opts...)
+}
+
+// newTLSConfig creates the TLS configuration from the certificates in the Secrets referenced by the endpoint
+func newTLSConfig(ctx context.Context, secrets client.Reader, namespace string, mirrorTLS *MirrorTLS) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if mirrorTLS.CA != nil {
+		ca, err := getSecretKey(ctx, secrets, namespace, mirrorTLS.CA)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("%w: %s/%s", ErrInvalidCertificate, mirrorTLS.CA.Name, mirrorTLS.CA.Key)
+		}
+	}
+
+	if mirrorTLS.Certificate != nil && mirrorTLS.Key != nil {
+		certificate, err := getSecretKey(ctx, secrets, namespace, mirrorTLS.Certificate)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := getSecretKey(ctx, secrets, namespace, mirrorTLS.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		keyPair, err := tls.X509KeyPair(certificate, key)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s/%s: %w", ErrInvalidCertificate, mirrorTLS.Certificate.Name, mirrorTLS.Certificate.Key, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{keyPair}
+	}
+
+	return tlsConfig, nil
+}
+
+// getSecretKey returns the value of the Secret key in the namespace
+func getSecretKey(
+	ctx context.Context,
+	reader client.Reader,
+	namespace string,
+	selector *corev1.SecretKeySelector,
+) ([]byte, error) {
+	secret := &corev1.Secret{}
+	if err := reader.Get(ctx, types.NamespacedName{Name: selector.Name, Namespace: namespace}, secret); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrSecretKeyNotFound, selector.Name, err)
+	}
+
+	value, ok := secret.Data[selector.Key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s/%s", ErrSecretKeyNotFound, selector.Name, selector.Key)
+	}
+
+	return value, nil
+}
+
+// Sync copies all versions of the subjects matching the subject pattern from the source to the destination,
+// preserving schema IDs and version numbers. Nothing is copied while any subject in the destination has diverged
+// from the source, instead the mirror is paused until the divergence is resolved. A subject which fails to
+// synchronize does not stop the others, it is reported in its status and retried on the next synchronization.
+func (m *SchemaMirror) Sync(
+	ctx context.Context,
+	source *srclient.ClientWithResponses,
+	destination *srclient.ClientWithResponses,
+	logger logr.Logger,
+) error {
+	pattern, err := regexp.Compile("^(?:" + m.Spec.SubjectPattern + ")$")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSubjectPattern, err)
+	}
+
+	subjects, err := listSubjects(ctx, source, true)
+	if err != nil {
+		return err
+	}
+
+	previous := make(map[string]SubjectMirrorStatus, len(m.Status.Subjects))
+	for _, subjectStatus := range m.Status.Subjects {
+		previous[subjectStatus.Name] = subjectStatus
+	}
+
+	statuses := make([]SubjectMirrorStatus, 0, len(subjects))
+	sourceVersions := make([]*subjectVersions, 0, len(subjects))
+	destinationVersions := make([]*subjectVersions, 0, len(subjects))
+	var diverged, failed []string
+	for _, subject := range subjects {
+		if !pattern.MatchString(subject) {
+			continue
+		}
+
+		subjectStatus, ok := previous[subject]
+		if !ok {
+			subjectStatus = SubjectMirrorStatus{Name: subject}
+		}
+		subjectStatus.Message = ""
+
+		sourceSubject, destinationSubject, err := compareSubject(ctx, source, destination, &subjectStatus)
+		switch {
+		case err != nil:
+			logger.Error(err, "failed to compare subject", "Subject", subject)
+			subjectStatus.Message = err.Error()
+			failed = append(failed, subject)
+		case subjectStatus.Divergence != "":
+			diverged = append(diverged, subject)
+		}
+
+		statuses = append(statuses, subjectStatus)
+		sourceVersions = append(sourceVersions, sourceSubject)
+		destinationVersions = append(destinationVersions, destinationSubject)
+	}
+
+	m.Status.Subjects = statuses
+	if len(diverged) > 0 {
+		logger.Info("Destination has diverged from source, pausing mirror", "Subjects", diverged)
+		m.setLag()
+		meta.SetStatusCondition(&m.Status.Conditions, metav1.Condition{
+			Type:               SchemaMirrorConditionPaused,
+			Status:             metav1.ConditionTrue,
+			Reason:             SchemaMirrorReasonDiverged,
+			Message:            "Destination has diverged for subjects: " + strings.Join(diverged, ", "),
+			ObservedGeneration: m.Generation,
+		})
+		m.UpdateStatus(false, "Mirroring paused, destination has diverged from source")
+		return nil
+	}
+
+	meta.SetStatusCondition(&m.Status.Conditions, metav1.Condition{
+		Type:               SchemaMirrorConditionPaused,
+		Status:             metav1.ConditionFalse,
+		Reason:             SchemaMirrorReasonInSync,
+		Message:            "Destination has not diverged from source",
+		ObservedGeneration: m.Generation,
+	})
+
+	// The purpose is to import the subjects referenced by other subjects first, as a schema can only be imported
+	// once the schemas it references exist in the destination
+	pending := make([]archive.Subject, 0, len(m.Status.Subjects))
+	indices := make(map[string]int, len(m.Status.Subjects))
+	for i := range m.Status.Subjects {
+		subjectStatus := &m.Status.Subjects[i]
+		if subjectStatus.Message != "" {
+			continue
+		}
+
+		missing, err := getMissingVersions(ctx, source, subjectStatus.Name, sourceVersions[i], destinationVersions[i])
+		if err != nil {
+			logger.Error(err, "failed to get missing versions", "Subject", subjectStatus.Name)
+			subjectStatus.Message = err.Error()
+			failed = append(failed, subjectStatus.Name)
+			continue
+		}
+
+		pending = append(pending, archive.Subject{Name: subjectStatus.Name, Versions: missing})
+		indices[subjectStatus.Name] = i
+	}
+
+	for _, subject := range archive.OrderByReferences(pending) {
+		i := indices[subject.Name]
+		err = syncSubject(ctx, destination, &m.Status.Subjects[i], subject.Versions, sourceVersions[i], destinationVersions[i])
+		if err != nil {
+			logger.Error(err, "failed to mirror subject", "Subject", subject.Name)
+			m.Status.Subjects[i].Message = err.Error()
+			failed = append(failed, subject.Name)
+		}
+	}
+
+	m.setLag()
+	if len(failed) > 0 {
+		m.UpdateStatus(false, fmt.Sprintf("Failed to mirror %d of %d subjects: %s",
+			len(failed), len(m.Status.Subjects), strings.Join(failed, ", ")))
+		return nil
+	}
+
+	m.UpdateStatus(true, fmt.Sprintf("Mirrored %d subjects", len(m.Status.Subjects)))
+	return nil
+}
+
+// compareSubject reads the versions of the subject in the source and destination, and records the latest version
+// and the divergence of the destination in the subject status
+func compareSubject(
+	ctx context.Context,
+	source *srclient.ClientWithResponses,
+	destination *srclient.ClientWithResponses,
+	subjectStatus *SubjectMirrorStatus,
+) (*subjectVersions, *subjectVersions, error) {
+	sourceSubject, err := getSubjectVersions(ctx, source, subjectStatus.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	destinationSubject, err := getSubjectVersions(ctx, destination, subjectStatus.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	subjectStatus.Divergence, err = findDivergence(ctx, source, destination, subjectStatus.Name,
+		sourceSubject, destinationSubject, subjectStatus.LastSyncedVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(sourceSubject.all) > 0 {
+		subjectStatus.LatestVersion = sourceSubject.all[len(sourceSubject.all)-1]
+	}
+	subjectStatus.Lag = subjectStatus.LatestVersion - subjectStatus.LastSyncedVersion
+
+	return sourceSubject, destinationSubject, nil
+}
+
+// getMissingVersions reads the source versions which do not exist in the destination
+func getMissingVersions(
+	ctx context.Context,
+	source *srclient.ClientWithResponses,
+	subject string,
+	sourceSubject *subjectVersions,
+	destinationSubject *subjectVersions,
+) ([]archive.Version, error) {
+	var missing []archive.Version
+	for _, version := range sourceSubject.all {
+		if destinationSubject.exists[version] {
+			continue
+		}
+
+		schema, err := getSchemaByVersion(ctx, source, subject, version, true)
+		if err != nil {
+			return nil, err
+		}
+
+		missing = append(missing, archiveVersion(version, schema, !sourceSubject.active[version]))
+	}
+
+	return missing, nil
+}
+
+func (m *SchemaMirror) setLag() {
+	m.Status.Lag = 0
+	for _, subjectStatus := range m.Status.Subjects {
+		m.Status.Lag += subjectStatus.Lag
+	}
+}
+
+func getSubjectVersions(
+	ctx context.Context,
+	srClient *srclient.ClientWithResponses,
+	subject string,
+) (*subjectVersions, error) {
+	all, err := listVersions(ctx, srClient, subject, true)
+	if err != nil {
+		return nil, err
+	}
+
+	active, err := listVersions(ctx, srClient, subject, false)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := &subjectVersions{
+		all:    all,
+		exists: make(map[int32]bool, len(all)),
+		active: make(map[int32]bool, len(active)),
+	}
+	for _, version := range all {
+		versions.exists[version] = true
+	}
+	for _, version := range active {
+		versions.active[version] = true
+	}
+
+	return versions, nil
+}
+
+// findDivergence compares the destination versions which were not copied by the mirror with the source,
+// and describes the first version which does not exist in the source or has a different schema ID
+func findDivergence(
+	ctx context.Context,
+	source *srclient.ClientWithResponses,
+	destination *srclient.ClientWithResponses,
+	subject string,
+	sourceSubject *subjectVersions,
+	destinationSubject *subjectVersions,
+	lastSyncedVersion int32,
+) (string, error) {
+	for _, version := range destinationSubject.all {
+		if version <= lastSyncedVersion {
+			continue
+		}
+
+		if !sourceSubject.exists[version] {
+			return "version " + strconv.Itoa(int(version)) + " only exists in the destination", nil
+		}
+
+		sourceSchema, err := getSchemaByVersion(ctx, source, subject, version, true)
+		if err != nil {
+			return "", err
+		}
+
+		destinationSchema, err := getSchemaByVersion(ctx, destination, subject, version, true)
+		if err != nil {
+			return "", err
+		}
+
+		sourceID := ptr.Deref(sourceSchema.Id, 0)
+		destinationID := ptr.Deref(destinationSchema.Id, 0)
+		if sourceID != destinationID {
+			return fmt.Sprintf("version %d has id %d in the destination but %d in the source",
+				version, destinationID, sourceID), nil
+		}
+	}
+
+	return "", nil
+}
+
+// syncSubject imports the missing versions in the destination in IMPORT mode,
+// and soft deletes the versions in the destination which are soft deleted in the source
+func syncSubject(
+	ctx context.Context,
+	destination *srclient.ClientWithResponses,
+	subjectStatus *SubjectMirrorStatus,
+	missing []archive.Version,
+	sourceSubject *subjectVersions,
+	destinationSubject *subjectVersions,
+) error {
+	subject := subjectStatus.Name
+	for _, version := range sourceSubject.all {
+		if !destinationSubject.exists[version] {
+			continue
+		}
+
+		if !sourceSubject.active[version] && destinationSubject.active[version] {
+			if err := softDeleteVersion(ctx, destination, subject, version); err != nil {
+				return err
+			}
+		}
+
+		subjectStatus.LastSyncedVersion = max(subjectStatus.LastSyncedVersion, version)
+	}
+
+	if len(missing) > 0 {
+		if err := setSubjectMode(ctx, destination, subject, string(srclient.ModeUpdateRequestModeIMPORT)); err != nil {
+			return err
+		}
+	}
+
+	for i := range missing {
+		version := &missing[i]
+		if err := importVersion(ctx, destination, subject, version); err != nil {
+			return err
+		}
+
+		if version.Deleted {
+			if err := softDeleteVersion(ctx, destination, subject, version.Version); err != nil {
+				return err
+			}
+		}
+
+		subjectStatus.LastSyncedVersion = max(subjectStatus.LastSyncedVersion, version.Version)
+		subjectStatus.Lag = subjectStatus.LatestVersion - subjectStatus.LastSyncedVersion
+	}
+
+	subjectStatus.Lag = subjectStatus.LatestVersion - subjectStatus.LastSyncedVersion
+	subjectStatus.LastSyncTime = ptr.To(metav1.Now())
+	return nil
+}
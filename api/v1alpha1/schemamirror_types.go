@@ -0,0 +1,189 @@
+/*
+MIT License
+
+Copyright (c) 2025 Steffen Karlsson
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	SchemaMirrorConditionPaused = "Paused"
+	SchemaMirrorReasonDiverged  = "DestinationDiverged"
+	SchemaMirrorReasonInSync    = "DestinationInSync"
+)
+
+// SchemaMirrorSpec defines the desired state of SchemaMirror
+type SchemaMirrorSpec struct {
+	// +kubebuilder:validation:Required
+	// Used to define the schema registry subjects are copied from
+	Source MirrorEndpoint `json:"source"`
+
+	// +kubebuilder:validation:Required
+	// Used to define the schema registry subjects are copied to, in IMPORT mode
+	Destination MirrorEndpoint `json:"destination"`
+
+	// +kubebuilder:default:=".*"
+	// +kubebuilder:validation:Optional
+	// Used to define the regular expression subjects must match to be mirrored, default is all subjects
+	SubjectPattern string `json:"subjectPattern" default:".*"`
+
+	// +kubebuilder:default:="1m"
+	// +kubebuilder:validation:Optional
+	// Used to define the interval between synchronizations, default is 1m
+	Interval metav1.Duration `json:"interval"`
+}
+
+// MirrorEndpoint defines a schema registry, either deployed by the operator or external
+// +kubebuilder:validation:XValidation:rule="has(self.instance) != has(self.url)",message="exactly one of instance or url must be set"
+type MirrorEndpoint struct {
+	// +kubebuilder:validation:Optional
+	// Used to define the name of a SchemaRegistry in the same namespace
+	Instance string `json:"instance,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// Used to define the URL of an external schema registry
+	URL string `json:"url,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define the basic authentication credentials of the schema registry
+	BasicAuth *MirrorBasicAuth `json:"basicAuth,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define the TLS configuration used to connect to the schema registry
+	TLS *MirrorTLS `json:"tls,omitempty"`
+}
+
+// MirrorBasicAuth defines the Secret keys holding the basic authentication credentials of a schema registry
+type MirrorBasicAuth struct {
+	// +kubebuilder:validation:Required
+	// Used to define the key of a Secret in the namespace of the mirror holding the username
+	Username corev1.SecretKeySelector `json:"username"`
+
+	// +kubebuilder:validation:Required
+	// Used to define the key of a Secret in the namespace of the mirror holding the password
+	Password corev1.SecretKeySelector `json:"password"`
+}
+
+// MirrorTLS defines the Secret keys holding the certificates used to connect to a schema registry
+// +kubebuilder:validation:XValidation:rule="has(self.certificate) == has(self.key)",message="certificate and key must be set together"
+type MirrorTLS struct {
+	// +kubebuilder:validation:Optional
+	// Used to define the key of a Secret holding the PEM encoded CA certificate the server certificate is verified
+	// with, default is the system CA certificates
+	CA *corev1.SecretKeySelector `json:"ca,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define the key of a Secret holding the PEM encoded client certificate
+	Certificate *corev1.SecretKeySelector `json:"certificate,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define the key of a Secret holding the PEM encoded private key of the client certificate
+	Key *corev1.SecretKeySelector `json:"key,omitempty"`
+}
+
+// SubjectMirrorStatus defines the mirroring progress of a single subject
+type SubjectMirrorStatus struct {
+	// Used to define the name of the subject
+	Name string `json:"name"`
+
+	// Used to define the latest version of the subject in the source
+	LatestVersion int32 `json:"latestVersion"`
+
+	// Used to define the latest version of the subject copied to the destination
+	LastSyncedVersion int32 `json:"lastSyncedVersion"`
+
+	// Used to define the number of versions the destination is behind the source
+	Lag int32 `json:"lag"`
+
+	// Used to define when the subject was last synchronized
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// Used to define why the subject in the destination has diverged from the source
+	Divergence string `json:"divergence,omitempty"`
+
+	// Used to define the error message of the latest synchronization of the subject
+	Message string `json:"message,omitempty"`
+}
+
+// SchemaMirrorStatus defines the observed state of SchemaMirror
+type SchemaMirrorStatus struct {
+	// Used to define the status message of the mirror
+	Message string `json:"message,omitempty"`
+
+	// Used to define if the destination is in sync with the source
+	Ready bool `json:"ready"`
+
+	// Used to define the total number of versions the destination is behind the source
+	Lag int32 `json:"lag"`
+
+	// Used to define the mirroring progress per subject
+	Subjects []SubjectMirrorStatus `json:"subjects,omitempty"`
+
+	// Used to define the conditions of the mirror
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Used to define the last transition time
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Pattern",type="string",JSONPath=".spec.subjectPattern",description="The pattern of mirrored subjects"
+// +kubebuilder:printcolumn:name="Lag",type="integer",JSONPath=".status.lag",description="The number of versions the destination is behind"
+// +kubebuilder:printcolumn:name="Paused",type="string",JSONPath=".status.conditions[?(@.type==\"Paused\")].status",description="If mirroring is paused"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready",description="If the destination is in sync"
+
+// SchemaMirror is the Schema for the schemamirrors API
+type SchemaMirror struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SchemaMirrorSpec   `json:"spec,omitempty"`
+	Status SchemaMirrorStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SchemaMirrorList contains a list of SchemaMirror
+type SchemaMirrorList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SchemaMirror `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SchemaMirror{}, &SchemaMirrorList{})
+}
+
+// UpdateStatus updates the status of the mirror
+func (m *SchemaMirror) UpdateStatus(ready bool, message string) {
+	m.Status.Ready = ready
+	m.Status.Message = message
+	m.Status.LastTransitionTime = metav1.Now()
+}
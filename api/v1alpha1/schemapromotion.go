@@ -0,0 +1,24 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ApprovedBy returns who approved a promotion which would break compatibility, as set by the approved-by annotation
+func (p *SchemaPromotion) ApprovedBy() string {
+	return p.Annotations[ApprovedByAnnotation]
+}
+
+// Audit appends a step to the audit trail, unless it repeats the latest step
+func (p *SchemaPromotion) Audit(event, message string) {
+	if trail := p.Status.AuditTrail; len(trail) > 0 &&
+		trail[len(trail)-1].Event == event && trail[len(trail)-1].Message == message {
+		return
+	}
+
+	p.Status.AuditTrail = append(p.Status.AuditTrail, PromotionAuditEntry{
+		Time:    metav1.Now(),
+		Event:   event,
+		Message: message,
+	})
+}
@@ -0,0 +1,161 @@
+/*
+MIT License
+
+Copyright (c) 2025 Steffen Karlsson
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	PromotionPhasePending          = "Pending"
+	PromotionPhaseAwaitingApproval = "AwaitingApproval"
+	PromotionPhasePromoted         = "Promoted"
+
+	PromotionEventSourceResolved       = "SourceResolved"
+	PromotionEventCompatibilityChecked = "CompatibilityChecked"
+	PromotionEventApprovalRequired     = "ApprovalRequired"
+	PromotionEventApproved             = "Approved"
+	PromotionEventFailed               = "Failed"
+	PromotionEventPromoted             = "Promoted"
+)
+
+// SchemaPromotionSpec defines the desired state of SchemaPromotion
+type SchemaPromotionSpec struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="schema is immutable"
+	// Used to define the name of the Schema whose subject is promoted, in the same namespace
+	Schema string `json:"schema"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="version is immutable"
+	// Used to define the version to promote, default is the latest version in the source when the promotion starts
+	Version int32 `json:"version,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="source is immutable"
+	// Used to define the name of the SchemaRegistry the version is promoted from, default is the first
+	// SchemaRegistry of the Schema
+	Source string `json:"source,omitempty"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="target is immutable"
+	// Used to define the name of the SchemaRegistry the version is promoted to
+	Target string `json:"target"`
+}
+
+// PromotionAuditEntry defines a step of a promotion
+type PromotionAuditEntry struct {
+	// Used to define when the step happened
+	Time metav1.Time `json:"time"`
+
+	// Used to define the step, one of SourceResolved, CompatibilityChecked, ApprovalRequired, Approved, Failed, Promoted
+	Event string `json:"event"`
+
+	// Used to define the details of the step
+	Message string `json:"message,omitempty"`
+}
+
+// SchemaPromotionStatus defines the observed state of SchemaPromotion
+type SchemaPromotionStatus struct {
+	// Used to define the status message of the promotion
+	Message string `json:"message,omitempty"`
+
+	// Used to define if the promotion is completed
+	Ready bool `json:"ready"`
+
+	// Used to define the phase of the promotion, one of Pending, AwaitingApproval, Promoted
+	Phase string `json:"phase,omitempty"`
+
+	// Used to define the promoted subject
+	Subject string `json:"subject,omitempty"`
+
+	// Used to define the name of the SchemaRegistry the version is promoted from
+	SourceRegistry string `json:"sourceRegistry,omitempty"`
+
+	// Used to define the promoted version in the source
+	SourceVersion int32 `json:"sourceVersion,omitempty"`
+
+	// Used to define if the promoted version is compatible with the latest version in the target
+	Compatible bool `json:"compatible"`
+
+	// Used to define the reasons the promoted version is incompatible with the target
+	CompatibilityMessages []string `json:"compatibilityMessages,omitempty"`
+
+	// Used to define the version registered in the target
+	TargetVersion int32 `json:"targetVersion,omitempty"`
+
+	// Used to define the global schema ID of the version registered in the target
+	TargetSchemaID int32 `json:"targetSchemaId,omitempty"`
+
+	// Used to define the steps of the promotion
+	AuditTrail []PromotionAuditEntry `json:"auditTrail,omitempty"`
+
+	// Used to define when the promotion was completed
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Used to define the last transition time
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Schema",type="string",JSONPath=".spec.schema",description="The schema being promoted"
+// +kubebuilder:printcolumn:name="Source",type="string",JSONPath=".status.sourceRegistry",description="The schema registry promoted from"
+// +kubebuilder:printcolumn:name="Target",type="string",JSONPath=".spec.target",description="The schema registry promoted to"
+// +kubebuilder:printcolumn:name="Version",type="integer",JSONPath=".status.sourceVersion",description="The promoted version in the source"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="The phase of the promotion"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready",description="The completion of the promotion"
+
+// SchemaPromotion is the Schema for the schemapromotions API
+type SchemaPromotion struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SchemaPromotionSpec   `json:"spec,omitempty"`
+	Status SchemaPromotionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SchemaPromotionList contains a list of SchemaPromotion
+type SchemaPromotionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SchemaPromotion `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SchemaPromotion{}, &SchemaPromotionList{})
+}
+
+// UpdateStatus updates the status of the promotion
+func (p *SchemaPromotion) UpdateStatus(ready bool, message string) {
+	p.Status.Ready = ready
+	p.Status.Message = message
+	p.Status.LastTransitionTime = metav1.Now()
+}
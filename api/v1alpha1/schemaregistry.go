@@ -2,19 +2,134 @@ package v1alpha1
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/steffen-karlsson/schema-registry-operator/pkg/archive"
 	"github.com/steffen-karlsson/schema-registry-operator/pkg/srclient"
 )
 
+// ConfigEnvName converts a schema registry property to the name of its environmental variable, following the
+// convention of the Confluent images where '.' is replaced by '_', '-' by '__' and '_' by '___'
+func ConfigEnvName(property string) string {
+	replacer := strings.NewReplacer("_", "___", "-", "__", ".", "_")
+	return ConfigEnvPrefix + strings.ToUpper(replacer.Replace(property))
+}
+
+// ValidateConfig checks that neither the config nor the additional config override the properties managed by
+// the operator, and that the config does not set the same environmental variable as the additional config
+func (s *SchemaRegistry) ValidateConfig() error {
+	managed := make(map[string]bool, len(ManagedConfig))
+	for _, property := range ManagedConfig {
+		managed[ConfigEnvName(property)] = true
+	}
+
+	additional := make(map[string]bool, len(s.Spec.AdditionalConfig))
+	var rejected []string
+	for _, env := range s.Spec.AdditionalConfig {
+		additional[env.Name] = true
+		if managed[env.Name] && !slices.Contains(rejected, env.Name) {
+			rejected = append(rejected, env.Name)
+		}
+	}
+
+	var conflicting []string
+	for property := range s.Spec.Config {
+		name := ConfigEnvName(property)
+		switch {
+		case managed[name]:
+			rejected = append(rejected, property)
+		case additional[name]:
+			conflicting = append(conflicting, property)
+		}
+	}
+
+	if len(rejected) > 0 {
+		sort.Strings(rejected)
+		return fmt.Errorf("%w: %s", ErrManagedConfig, strings.Join(rejected, ", "))
+	}
+
+	if len(conflicting) > 0 {
+		sort.Strings(conflicting)
+		return fmt.Errorf("%w: %s", ErrConflictingConfig, strings.Join(conflicting, ", "))
+	}
+
+	return nil
+}
+
+// SetConfigValid reports the result of ValidateConfig as a condition
+func (s *SchemaRegistry) SetConfigValid(err error) {
+	condition := metav1.Condition{
+		Type:               SchemaRegistryConditionConfigValid,
+		Status:             metav1.ConditionTrue,
+		Reason:             SchemaRegistryReasonValid,
+		Message:            "Config is valid",
+		ObservedGeneration: s.Generation,
+	}
+
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = SchemaRegistryReasonManagedConfig
+		condition.Message = err.Error()
+		if errors.Is(err, ErrConflictingConfig) {
+			condition.Reason = SchemaRegistryReasonConflictingConfig
+		}
+	}
+
+	meta.SetStatusCondition(&s.Status.Conditions, condition)
+}
+
+// ConfigEnvs returns the config as environmental variables, sorted by name
+func (s *SchemaRegistry) ConfigEnvs() []corev1.EnvVar {
+	envs := make([]corev1.EnvVar, 0, len(s.Spec.Config))
+	for property, value := range s.Spec.Config {
+		env := corev1.EnvVar{
+			Name:  ConfigEnvName(property),
+			Value: value.Value,
+		}
+
+		if value.ValueFrom != nil {
+			env.ValueFrom = &corev1.EnvVarSource{
+				SecretKeyRef:    value.ValueFrom.SecretKeyRef,
+				ConfigMapKeyRef: value.ValueFrom.ConfigMapKeyRef,
+			}
+		}
+
+		envs = append(envs, env)
+	}
+
+	sort.Slice(envs, func(i, j int) bool {
+		return envs[i].Name < envs[j].Name
+	})
+
+	return envs
+}
+
+// MaxReplicas returns the maximum number of replicas, which is the upper bound of the autoscaling when defined
+func (s *SchemaRegistry) MaxReplicas() int32 {
+	if s.Spec.Autoscaling != nil {
+		return s.Spec.Autoscaling.MaxReplicas
+	}
+
+	return s.Spec.Replicas
+}
+
 // NewInstance creates a new instance of the SchemaRegistry CRD
 func (s *SchemaRegistry) NewInstance(
 	ctx context.Context,
@@ -54,10 +169,7 @@ func (s *SchemaRegistry) DeploySchema(
 
 	registerResp, err := srClient.Register1WithResponse(ctx, schema.GetSubject(), &srclient.Register1Params{
 		Normalize: &schema.Spec.Normalize,
-	}, srclient.Register1JSONRequestBody{
-		Schema:     &schema.Spec.Content,
-		SchemaType: &schema.Spec.Type,
-	})
+	}, schema.registerSchemaRequest())
 
 	if err != nil {
 		logger.Error(err, "failed to register schema")
@@ -79,6 +191,140 @@ func (s *SchemaRegistry) DeploySchema(
 	return getResp.ApplicationvndSchemaregistryV1JSON200, nil
 }
 
+// PlanSchema performs the compatibility check and look up of the schema without changing the schema registry,
+// and returns the changes deploying the schema would make, and whether the schema registry would accept them
+func (s *SchemaRegistry) PlanSchema(
+	ctx context.Context,
+	schema *Schema,
+	logger logr.Logger,
+) ([]string, bool, error) {
+	srClient, err := s.newClient()
+	if err != nil {
+		logger.Error(err, "failed to create schema registry client")
+		return nil, false, err
+	}
+
+	subject := schema.GetSubject()
+	request := schema.registerSchemaRequest()
+
+	var plan []string
+	registered, err := lookUpSchema(ctx, srClient, subject, schema.Spec.Normalize, request)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if registered != nil {
+		plan = append(plan, fmt.Sprintf("schema is already registered as version %d", ptr.Deref(registered.Version, 0)))
+	} else {
+		compatibility, err := testCompatibility(ctx, srClient, subject, SchemaVersionLatest, schema.Spec.Normalize, request)
+		switch {
+		case errors.Is(err, ErrInvalidSchemaOrType):
+			return []string{"would reject the schema: " + err.Error()}, false, nil
+		case err != nil:
+			return nil, false, err
+		}
+
+		versions, err := listVersions(ctx, srClient, subject, false)
+		if err != nil {
+			return nil, false, err
+		}
+
+		nextVersion := 1
+		if len(versions) > 0 {
+			nextVersion = int(versions[len(versions)-1]) + 1
+		}
+
+		if compatibility != nil && !ptr.Deref(compatibility.IsCompatible, false) {
+			plan = append(plan, fmt.Sprintf("would reject version %d as incompatible: %s",
+				nextVersion, strings.Join(ptr.Deref(compatibility.Messages, nil), "; ")))
+			return plan, false, nil
+		}
+
+		plan = append(plan, fmt.Sprintf("would register version %d", nextVersion))
+	}
+
+	expired, err := expiredVersions(ctx, srClient, s.Name, schema, logger)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, version := range expired {
+		plan = append(plan, fmt.Sprintf("would delete version %d by the retention policy", version))
+	}
+
+	config, err := getSubjectConfig(ctx, srClient, subject, true)
+	if err != nil {
+		return nil, false, err
+	}
+
+	current := ""
+	if config != nil {
+		current = string(ptr.Deref(config.CompatibilityLevel, ""))
+	}
+
+	if current != schema.Spec.CompatibilityLevel {
+		plan = append(plan, fmt.Sprintf("would change compatibility %s→%s", current, schema.Spec.CompatibilityLevel))
+	}
+
+	return plan, true, nil
+}
+
+// GetSchemaVersion returns a version of a subject in the schema registry, or the latest version when version is 0
+func (s *SchemaRegistry) GetSchemaVersion(
+	ctx context.Context,
+	subject string,
+	version int32,
+	logger logr.Logger,
+) (*srclient.Schema, error) {
+	srClient, err := s.newClient()
+	if err != nil {
+		logger.Error(err, "failed to create schema registry client")
+		return nil, err
+	}
+
+	versions, err := listVersions(ctx, srClient, subject, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if version == 0 && len(versions) > 0 {
+		version = versions[len(versions)-1]
+	}
+
+	if !slices.Contains(versions, version) {
+		return nil, fmt.Errorf("%w: %s %d", ErrVersionNotFound, subject, version)
+	}
+
+	return getSchemaByVersion(ctx, srClient, subject, version, false)
+}
+
+// TestCompatibility tests the schema against the latest version of its subject in the schema registry,
+// and returns whether it is compatible together with the reasons when it is not
+func (s *SchemaRegistry) TestCompatibility(
+	ctx context.Context,
+	schema *Schema,
+	logger logr.Logger,
+) (bool, []string, error) {
+	srClient, err := s.newClient()
+	if err != nil {
+		logger.Error(err, "failed to create schema registry client")
+		return false, nil, err
+	}
+
+	compatibility, err := testCompatibility(ctx, srClient, schema.GetSubject(), SchemaVersionLatest,
+		schema.Spec.Normalize, schema.registerSchemaRequest())
+	if err != nil {
+		return false, nil, err
+	}
+
+	// A subject without versions is compatible with any schema
+	if compatibility == nil || ptr.Deref(compatibility.IsCompatible, false) {
+		return true, nil, nil
+	}
+
+	return false, ptr.Deref(compatibility.Messages, nil), nil
+}
+
 // DeleteSchema deletes a schema from the schema registry
 func (s *SchemaRegistry) DeleteSchema(
 	ctx context.Context,
@@ -86,13 +332,74 @@ func (s *SchemaRegistry) DeleteSchema(
 	logger logr.Logger,
 ) error {
 	logger.Info("Deleting schema in schema registry", "Name", schema.Name, "Namespace", schema.Namespace)
+	return s.DeleteSubject(ctx, schema.GetSubject(), logger)
+}
+
+// FindReferencingSubjects returns the other subjects with schemas referencing any version of the schema,
+// including soft deleted versions, as deleting the schema permanently deletes all of them
+func (s *SchemaRegistry) FindReferencingSubjects(
+	ctx context.Context,
+	schema *Schema,
+	logger logr.Logger,
+) ([]string, error) {
+	srClient, err := s.newClient()
+	if err != nil {
+		logger.Error(err, "failed to create schema registry client")
+		return nil, err
+	}
+
+	return findReferencingSubjects(ctx, srClient, schema.GetSubject())
+}
+
+// findReferencingSubjects returns the other subjects with schemas referencing any version of the subject
+func findReferencingSubjects(
+	ctx context.Context,
+	srClient *srclient.ClientWithResponses,
+	subject string,
+) ([]string, error) {
+	versions, err := listVersions(ctx, srClient, subject, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var referencing []string
+	for _, version := range versions {
+		ids, err := getReferencedBy(ctx, srClient, subject, version)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, id := range ids {
+			subjects, err := getSubjectsByID(ctx, srClient, id)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, referencingSubject := range subjects {
+				if referencingSubject != subject && !slices.Contains(referencing, referencingSubject) {
+					referencing = append(referencing, referencingSubject)
+				}
+			}
+		}
+	}
+	sort.Strings(referencing)
+
+	return referencing, nil
+}
+
+// DeleteSubject soft deletes and then hard deletes a subject in the schema registry
+func (s *SchemaRegistry) DeleteSubject(
+	ctx context.Context,
+	subject string,
+	logger logr.Logger,
+) error {
 	srClient, err := s.newClient()
 	if err != nil {
 		logger.Error(err, "failed to create schema registry client")
 		return err
 	}
 
-	softDeleteResp, err := srClient.DeleteSubject1WithResponse(ctx, schema.GetSubject(), nil)
+	softDeleteResp, err := srClient.DeleteSubject1WithResponse(ctx, subject, nil)
 	if err != nil {
 		return fmt.Errorf("%w: %w", ErrFailedToSoftDeleteSchema, err)
 	}
@@ -101,7 +408,7 @@ func (s *SchemaRegistry) DeleteSchema(
 		return fmt.Errorf("%w: %w", ErrFailedToSoftDeleteSchema, err)
 	}
 
-	hardDeleteResp, err := srClient.DeleteSubject1WithResponse(ctx, schema.GetSubject(), &srclient.DeleteSubject1Params{
+	hardDeleteResp, err := srClient.DeleteSubject1WithResponse(ctx, subject, &srclient.DeleteSubject1Params{
 		Permanent: ptr.To(true),
 	})
 	if err != nil {
@@ -115,6 +422,184 @@ func (s *SchemaRegistry) DeleteSchema(
 	return nil
 }
 
+// EnforceRetention deletes the versions of the schema expired by its retention policy,
+// soft deleting them first and then permanently, and returns the deleted versions
+func (s *SchemaRegistry) EnforceRetention(
+	ctx context.Context,
+	schema *Schema,
+	logger logr.Logger,
+) ([]int32, error) {
+	srClient, err := s.newClient()
+	if err != nil {
+		logger.Error(err, "failed to create schema registry client")
+		return nil, err
+	}
+
+	expired, err := expiredVersions(ctx, srClient, s.Name, schema, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	subject := schema.GetSubject()
+	var deleted []int32
+	for _, version := range expired {
+		logger.Info("Deleting expired schema version in schema registry", "Subject", subject, "Version", version)
+		if err = softDeleteVersion(ctx, srClient, subject, version); err != nil {
+			return deleted, err
+		}
+
+		if err = hardDeleteVersion(ctx, srClient, subject, version); err != nil {
+			return deleted, err
+		}
+
+		deleted = append(deleted, version)
+	}
+
+	return deleted, nil
+}
+
+// expiredVersions returns the versions of the schema expired by its retention policy,
+// leaving out the versions referenced by schemas of other subjects
+func expiredVersions(
+	ctx context.Context,
+	srClient *srclient.ClientWithResponses,
+	registry string,
+	schema *Schema,
+	logger logr.Logger,
+) ([]int32, error) {
+	if schema.Spec.Retention == nil {
+		return nil, nil
+	}
+
+	versions, err := listVersions(ctx, srClient, schema.GetSubject(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	var expired []int32
+	for _, version := range schema.ExpiredVersions(registry, versions, time.Now()) {
+		referencedBy, err := getReferencedBy(ctx, srClient, schema.GetSubject(), version)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(referencedBy) > 0 {
+			logger.Info("Keeping expired schema version referenced by other schemas",
+				"Subject", schema.GetSubject(), "Version", version, "ReferencedBy", referencedBy)
+			continue
+		}
+
+		expired = append(expired, version)
+	}
+
+	return expired, nil
+}
+
+// RollbackSchema rolls the subject of the schema back to the version of the rollback, either by registering
+// the content of the version as the new latest version, or by permanently deleting the newer versions.
+// It returns the latest version after the rollback and the deleted versions.
+func (s *SchemaRegistry) RollbackSchema(
+	ctx context.Context,
+	schema *Schema,
+	rollback *SchemaRollback,
+	logger logr.Logger,
+) (*srclient.Schema, []int32, error) {
+	srClient, err := s.newClient()
+	if err != nil {
+		logger.Error(err, "failed to create schema registry client")
+		return nil, nil, err
+	}
+
+	subject := schema.GetSubject()
+	version := rollback.Spec.RollbackToVersion
+	versions, err := listVersions(ctx, srClient, subject, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !slices.Contains(versions, version) {
+		return nil, nil, fmt.Errorf("%w: %s %d", ErrVersionNotFound, subject, version)
+	}
+
+	target, err := getSchemaByVersion(ctx, srClient, subject, version, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The target is already the latest version, so there is nothing to roll back
+	if versions[len(versions)-1] == version {
+		return target, nil, nil
+	}
+
+	if rollback.Spec.Policy == RollbackPolicyDeleteNewer {
+		var deleted []int32
+		for i := len(versions) - 1; versions[i] > version; i-- {
+			logger.Info("Deleting schema version in schema registry", "Subject", subject, "Version", versions[i])
+			if err = softDeleteVersion(ctx, srClient, subject, versions[i]); err != nil {
+				return nil, deleted, err
+			}
+
+			if err = hardDeleteVersion(ctx, srClient, subject, versions[i]); err != nil {
+				return nil, deleted, err
+			}
+
+			deleted = append(deleted, versions[i])
+		}
+
+		return target, deleted, nil
+	}
+
+	// The schema registry returns the existing version when identical content is registered again,
+	// so the rollback is recorded in the metadata to register the content as a new version
+	metadata := &srclient.Metadata{}
+	if target.Metadata != nil {
+		*metadata = *target.Metadata
+	}
+
+	properties := map[string]string{}
+	for key, value := range ptr.Deref(metadata.Properties, nil) {
+		properties[key] = value
+	}
+	properties[RollbackNameProperty] = rollback.Name
+	properties[RollbackVersionProperty] = strconv.Itoa(int(version))
+	metadata.Properties = &properties
+
+	logger.Info("Registering schema version in schema registry", "Subject", subject, "Version", version)
+	registerResp, err := srClient.Register1WithResponse(ctx, subject, &srclient.Register1Params{
+		Normalize: &schema.Spec.Normalize,
+	}, srclient.RegisterSchemaRequest{
+		Schema:     target.Schema,
+		SchemaType: target.SchemaType,
+		References: target.References,
+		Metadata:   metadata,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch registerResp.HTTPResponse.StatusCode {
+	case http.StatusUnprocessableEntity:
+		return nil, nil, NewInvalidSchemaOrTypeError(errorMessageOf(registerResp.ApplicationvndSchemaregistryV1JSON422))
+	case http.StatusConflict:
+		return nil, nil, NewIncompatibleSchemaError(errorMessageOf(registerResp.ApplicationvndSchemaregistryV1JSON409))
+	}
+
+	if registerResp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("unknown error, failed to register schema: %s", registerResp.Status())
+	}
+
+	if versions, err = listVersions(ctx, srClient, subject, false); err != nil {
+		return nil, nil, err
+	}
+
+	latest, err := getSchemaByVersion(ctx, srClient, subject, versions[len(versions)-1], false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return latest, nil, nil
+}
+
 // ChangeCompatibilityLevel changes the compatibility level of a schema in the schema registry
 func (s *SchemaRegistry) ChangeCompatibilityLevel(
 	ctx context.Context,
@@ -137,7 +622,544 @@ func (s *SchemaRegistry) ChangeCompatibilityLevel(
 	return nil
 }
 
+// SyncGlobalConfig applies the global compatibility level, normalization and mode to the schema registry
+// and reports the values read back from the schema registry in the status
+func (s *SchemaRegistry) SyncGlobalConfig(
+	ctx context.Context,
+	logger logr.Logger,
+) error {
+	srClient, err := s.newClient()
+	if err != nil {
+		logger.Error(err, "failed to create schema registry client")
+		return err
+	}
+
+	config, err := getGlobalConfig(ctx, srClient)
+	if err != nil {
+		return err
+	}
+
+	if string(ptr.Deref(config.CompatibilityLevel, "")) != s.Spec.CompatibilityLevel ||
+		ptr.Deref(config.Normalize, false) != s.Spec.Normalize {
+		logger.Info("Updating global config in schema registry",
+			"CompatibilityLevel", s.Spec.CompatibilityLevel, "Normalize", s.Spec.Normalize)
+
+		resp, err := srClient.UpdateTopLevelConfig1WithResponse(ctx, srclient.UpdateTopLevelConfig1JSONRequestBody{
+			Compatibility: ptr.To(srclient.ConfigUpdateRequestCompatibility(s.Spec.CompatibilityLevel)),
+			Normalize:     &s.Spec.Normalize,
+		})
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrFailedToSetGlobalConfig, err)
+		}
+
+		if resp.HTTPResponse.StatusCode != http.StatusOK {
+			return fmt.Errorf("%w: %s", ErrFailedToSetGlobalConfig, resp.Status())
+		}
+
+		if config, err = getGlobalConfig(ctx, srClient); err != nil {
+			return err
+		}
+	}
+
+	mode, err := getGlobalMode(ctx, srClient)
+	if err != nil {
+		return err
+	}
+
+	if s.Spec.Mode != "" && string(ptr.Deref(mode.Mode, "")) != s.Spec.Mode {
+		logger.Info("Updating global mode in schema registry", "Mode", s.Spec.Mode)
+
+		// Force is required to switch to IMPORT while the schema registry already contains schemas
+		resp, err := srClient.UpdateTopLevelMode1WithResponse(ctx, &srclient.UpdateTopLevelMode1Params{
+			Force: ptr.To(s.Spec.Mode == string(srclient.ModeUpdateRequestModeIMPORT)),
+		}, srclient.UpdateTopLevelMode1JSONRequestBody{
+			Mode: ptr.To(srclient.ModeUpdateRequestMode(s.Spec.Mode)),
+		})
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrFailedToSetGlobalMode, err)
+		}
+
+		if resp.HTTPResponse.StatusCode != http.StatusOK {
+			return fmt.Errorf("%w: %s", ErrFailedToSetGlobalMode, resp.Status())
+		}
+
+		if mode, err = getGlobalMode(ctx, srClient); err != nil {
+			return err
+		}
+	}
+
+	s.Status.CompatibilityLevel = string(ptr.Deref(config.CompatibilityLevel, ""))
+	s.Status.Normalize = ptr.Deref(config.Normalize, false)
+	s.Status.Mode = string(ptr.Deref(mode.Mode, ""))
+
+	return nil
+}
+
+// CheckHealth calls the server metadata endpoints of the schema registry, and reports the running version,
+// the Kafka cluster ID and the reachability of the API and the Kafka cluster as conditions in the status.
+// An error is returned when the API is not reachable.
+func (s *SchemaRegistry) CheckHealth(
+	ctx context.Context,
+	logger logr.Logger,
+) error {
+	srClient, err := s.newClient()
+	if err != nil {
+		logger.Error(err, "failed to create schema registry client")
+		return err
+	}
+
+	version, err := getServerVersion(ctx, srClient)
+	if err != nil {
+		s.setHealthConditions(metav1.ConditionFalse, SchemaRegistryReasonUnreachable, err.Error())
+		return err
+	}
+
+	s.Status.Version = version.Version
+	meta.SetStatusCondition(&s.Status.Conditions, metav1.Condition{
+		Type:               SchemaRegistryConditionAPIReachable,
+		Status:             metav1.ConditionTrue,
+		Reason:             SchemaRegistryReasonReachable,
+		Message:            fmt.Sprintf("Schema Registry %s (commit %s) is reachable", version.Version, version.CommitID),
+		ObservedGeneration: s.Generation,
+	})
+
+	clusterID, err := getClusterID(ctx, srClient)
+	if err != nil {
+		meta.SetStatusCondition(&s.Status.Conditions, metav1.Condition{
+			Type:               SchemaRegistryConditionKafkaClusterReachable,
+			Status:             metav1.ConditionFalse,
+			Reason:             SchemaRegistryReasonUnreachable,
+			Message:            err.Error(),
+			ObservedGeneration: s.Generation,
+		})
+		return nil
+	}
+
+	s.Status.KafkaClusterID = clusterID
+	meta.SetStatusCondition(&s.Status.Conditions, metav1.Condition{
+		Type:               SchemaRegistryConditionKafkaClusterReachable,
+		Status:             metav1.ConditionTrue,
+		Reason:             SchemaRegistryReasonReachable,
+		Message:            "Kafka cluster " + clusterID + " is reachable",
+		ObservedGeneration: s.Generation,
+	})
+
+	return nil
+}
+
+// SetHealthUnknown reports the health conditions as unknown while the deployment is not ready
+func (s *SchemaRegistry) SetHealthUnknown() {
+	s.setHealthConditions(metav1.ConditionUnknown, SchemaRegistryReasonDeploymentNotReady,
+		"The deployment of the Schema Registry is not ready")
+}
+
+// SetLeader reports the pod which is currently the leader, or that no pod reports itself as leader
+func (s *SchemaRegistry) SetLeader(leader string) {
+	s.Status.Leader = leader
+	condition := metav1.Condition{
+		Type:               SchemaRegistryConditionLeaderElected,
+		Status:             metav1.ConditionTrue,
+		Reason:             SchemaRegistryReasonLeaderFound,
+		Message:            "Pod " + leader + " is the leader",
+		ObservedGeneration: s.Generation,
+	}
+
+	if leader == "" {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = SchemaRegistryReasonNoLeader
+		condition.Message = "No leader eligible pod reports itself as leader"
+	}
+
+	meta.SetStatusCondition(&s.Status.Conditions, condition)
+}
+
+// SetLeaderUnknown reports the leader as unknown, as it is only read from the metrics of the pods
+func (s *SchemaRegistry) SetLeaderUnknown(reason string, message string) {
+	s.Status.Leader = ""
+	meta.SetStatusCondition(&s.Status.Conditions, metav1.Condition{
+		Type:               SchemaRegistryConditionLeaderElected,
+		Status:             metav1.ConditionUnknown,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: s.Generation,
+	})
+}
+
+func (s *SchemaRegistry) setHealthConditions(status metav1.ConditionStatus, reason string, message string) {
+	for _, conditionType := range []string{SchemaRegistryConditionAPIReachable, SchemaRegistryConditionKafkaClusterReachable} {
+		meta.SetStatusCondition(&s.Status.Conditions, metav1.Condition{
+			Type:               conditionType,
+			Status:             status,
+			Reason:             reason,
+			Message:            message,
+			ObservedGeneration: s.Generation,
+		})
+	}
+}
+
+// FindOrphanedSubjects returns the subjects in the schema registry which are not owned by any Schema bound to it
+func (s *SchemaRegistry) FindOrphanedSubjects(
+	ctx context.Context,
+	reader client.Reader,
+	logger logr.Logger,
+) ([]string, error) {
+	srClient, err := s.newClient()
+	if err != nil {
+		logger.Error(err, "failed to create schema registry client")
+		return nil, err
+	}
+
+	schemas := &SchemaList{}
+	if err = reader.List(ctx, schemas, client.InNamespace(s.Namespace)); err != nil {
+		return nil, err
+	}
+
+	owned := make(map[string]bool, len(schemas.Items))
+	for _, schema := range schemas.Items {
+		targeting, err := schema.IsTargeting(s)
+		if err != nil {
+			return nil, err
+		}
+
+		if targeting {
+			owned[schema.GetSubject()] = true
+		}
+	}
+
+	// The subjects promoted to the schema registry are owned by their promotions
+	promotions := &SchemaPromotionList{}
+	if err = reader.List(ctx, promotions, client.InNamespace(s.Namespace)); err != nil {
+		return nil, err
+	}
+
+	for _, promotion := range promotions.Items {
+		if promotion.Spec.Target == s.Name && promotion.Status.Subject != "" {
+			owned[promotion.Status.Subject] = true
+		}
+	}
+
+	// The subjects mirrored to the schema registry are owned by their mirrors
+	mirrors := &SchemaMirrorList{}
+	if err = reader.List(ctx, mirrors, client.InNamespace(s.Namespace)); err != nil {
+		return nil, err
+	}
+
+	for _, mirror := range mirrors.Items {
+		if mirror.Spec.Destination.Instance == s.Name {
+			for _, subject := range mirror.Status.Subjects {
+				owned[subject.Name] = true
+			}
+		}
+	}
+
+	// The subjects restored in the schema registry are owned by their restores
+	restores := &SchemaRegistryRestoreList{}
+	if err = reader.List(ctx, restores, client.InNamespace(s.Namespace),
+		client.MatchingLabels{SchemaRegistryLabelName: s.Name}); err != nil {
+		return nil, err
+	}
+
+	for _, restore := range restores.Items {
+		for _, subject := range restore.Status.Subjects {
+			owned[subject.Name] = true
+		}
+	}
+
+	subjects, err := listSubjects(ctx, srClient, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var orphaned []string
+	for _, subject := range subjects {
+		if !owned[subject] {
+			orphaned = append(orphaned, subject)
+		}
+	}
+	sort.Strings(orphaned)
+
+	return orphaned, nil
+}
+
+// PruneOrphanedSubjects deletes the orphaned subjects which do not match the allow-list and are not referenced
+// by other subjects, and returns the subjects which are kept. Subjects are soft deleted unless permanent deletion
+// is enabled.
+func (s *SchemaRegistry) PruneOrphanedSubjects(
+	ctx context.Context,
+	orphaned []string,
+	logger logr.Logger,
+) ([]string, error) {
+	srClient, err := s.newClient()
+	if err != nil {
+		logger.Error(err, "failed to create schema registry client")
+		return orphaned, err
+	}
+
+	allowList := make([]*regexp.Regexp, 0, len(s.Spec.OrphanedSubjects.AllowList))
+	for _, pattern := range s.Spec.OrphanedSubjects.AllowList {
+		allowed, err := regexp.Compile("^(?:" + pattern + ")$")
+		if err != nil {
+			return orphaned, fmt.Errorf("%w: %w", ErrInvalidSubjectPattern, err)
+		}
+		allowList = append(allowList, allowed)
+	}
+
+	var kept []string
+	var errs []error
+	for _, subject := range orphaned {
+		if slices.ContainsFunc(allowList, func(allowed *regexp.Regexp) bool { return allowed.MatchString(subject) }) {
+			kept = append(kept, subject)
+			continue
+		}
+
+		// The purpose is to never break the schemas referencing the subject
+		referencing, err := findReferencingSubjects(ctx, srClient, subject)
+		if err != nil {
+			kept = append(kept, subject)
+			errs = append(errs, fmt.Errorf("%s: %w", subject, err))
+			continue
+		}
+
+		if len(referencing) > 0 {
+			logger.Info("Not pruning orphaned subject referenced by other subjects", "Subject", subject,
+				"ReferencedBy", referencing)
+			kept = append(kept, subject)
+			continue
+		}
+
+		logger.Info("Pruning orphaned subject in schema registry", "Subject", subject,
+			"Permanent", s.Spec.OrphanedSubjects.Permanent)
+		if s.Spec.OrphanedSubjects.Permanent {
+			err = s.DeleteSubject(ctx, subject, logger)
+		} else {
+			err = softDeleteSubject(ctx, srClient, subject)
+		}
+		if err != nil {
+			kept = append(kept, subject)
+			errs = append(errs, fmt.Errorf("%s: %w", subject, err))
+		}
+	}
+
+	return kept, errors.Join(errs...)
+}
+
+// Export walks all subjects and versions in the schema registry and returns them as an archive
+func (s *SchemaRegistry) Export(
+	ctx context.Context,
+	includeDeleted bool,
+	logger logr.Logger,
+) (*archive.Archive, error) {
+	logger.Info("Exporting schema registry", "Name", s.Name, "Namespace", s.Namespace)
+	srClient, err := s.newClient()
+	if err != nil {
+		logger.Error(err, "failed to create schema registry client")
+		return nil, err
+	}
+
+	config, err := getGlobalConfig(ctx, srClient)
+	if err != nil {
+		return nil, err
+	}
+
+	mode, err := getGlobalMode(ctx, srClient)
+	if err != nil {
+		return nil, err
+	}
+
+	subjects, err := listSubjects(ctx, srClient, includeDeleted)
+	if err != nil {
+		return nil, err
+	}
+
+	activeSubjects, err := listSubjects(ctx, srClient, false)
+	if err != nil {
+		return nil, err
+	}
+
+	active := make(map[string]bool, len(activeSubjects))
+	for _, subject := range activeSubjects {
+		active[subject] = true
+	}
+
+	result := &archive.Archive{
+		FormatVersion: archive.FormatVersion,
+		CreatedAt:     time.Now().UTC(),
+		Registry:      s.Name,
+		Config:        config,
+		Mode:          string(ptr.Deref(mode.Mode, "")),
+	}
+
+	for _, subject := range subjects {
+		exported, err := exportSubject(ctx, srClient, subject, includeDeleted)
+		if err != nil {
+			return nil, err
+		}
+
+		exported.Deleted = !active[subject]
+		result.Subjects = append(result.Subjects, *exported)
+	}
+
+	return result, nil
+}
+
+func exportSubject(
+	ctx context.Context,
+	srClient *srclient.ClientWithResponses,
+	subject string,
+	includeDeleted bool,
+) (*archive.Subject, error) {
+	versions, err := listVersions(ctx, srClient, subject, includeDeleted)
+	if err != nil {
+		return nil, err
+	}
+
+	activeVersions, err := listVersions(ctx, srClient, subject, false)
+	if err != nil {
+		return nil, err
+	}
+
+	active := make(map[int32]bool, len(activeVersions))
+	for _, version := range activeVersions {
+		active[version] = true
+	}
+
+	exported := &archive.Subject{Name: subject}
+	for _, version := range versions {
+		schema, err := getSchemaByVersion(ctx, srClient, subject, version, includeDeleted)
+		if err != nil {
+			return nil, err
+		}
+
+		exported.Versions = append(exported.Versions, archiveVersion(version, schema, !active[version]))
+	}
+
+	if exported.Config, err = getSubjectConfig(ctx, srClient, subject, false); err != nil {
+		return nil, err
+	}
+
+	if exported.Mode, err = getSubjectMode(ctx, srClient, subject); err != nil {
+		return nil, err
+	}
+
+	return exported, nil
+}
+
+// archiveVersion converts a version read from the schema registry to its archived form
+func archiveVersion(version int32, schema *srclient.Schema, deleted bool) archive.Version {
+	return archive.Version{
+		Version:    version,
+		ID:         ptr.Deref(schema.Id, 0),
+		SchemaType: ptr.Deref(schema.SchemaType, ""),
+		Schema:     ptr.Deref(schema.Schema, ""),
+		References: ptr.Deref(schema.References, nil),
+		Metadata:   schema.Metadata,
+		RuleSet:    schema.RuleSet,
+		Deleted:    deleted,
+	}
+}
+
+// RestoreSubject imports all versions of an archived subject with their original schema IDs and version numbers.
+// The subject is switched to IMPORT mode while importing, and afterward its archived config and mode are restored.
+// Versions which already exist in the schema registry are skipped, so an interrupted restore can be resumed.
+func (s *SchemaRegistry) RestoreSubject(
+	ctx context.Context,
+	subject *archive.Subject,
+	includeDeleted bool,
+	logger logr.Logger,
+) (restored int, err error) {
+	logger.Info("Restoring subject in schema registry", "Subject", subject.Name, "Versions", len(subject.Versions))
+	srClient, err := s.newClient()
+	if err != nil {
+		logger.Error(err, "failed to create schema registry client")
+		return 0, err
+	}
+
+	existingVersions, err := listVersions(ctx, srClient, subject.Name, true)
+	if err != nil {
+		return 0, err
+	}
+
+	existing := make(map[int32]bool, len(existingVersions))
+	for _, version := range existingVersions {
+		existing[version] = true
+	}
+
+	previousMode, err := getSubjectMode(ctx, srClient, subject.Name)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = setSubjectMode(ctx, srClient, subject.Name, string(srclient.ModeUpdateRequestModeIMPORT)); err != nil {
+		return 0, err
+	}
+
+	// The purpose is to not leave the subject in IMPORT mode, which rejects registrations, when the restore fails
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		var resetErr error
+		if previousMode != "" {
+			resetErr = setSubjectMode(ctx, srClient, subject.Name, previousMode)
+		} else {
+			resetErr = deleteSubjectMode(ctx, srClient, subject.Name)
+		}
+		if resetErr != nil {
+			logger.Error(resetErr, "failed to reset subject mode", "Subject", subject.Name, "Mode", previousMode)
+		}
+	}()
+
+	for i := range subject.Versions {
+		version := &subject.Versions[i]
+		if version.Deleted && !includeDeleted {
+			continue
+		}
+
+		if !existing[version.Version] {
+			if err = importVersion(ctx, srClient, subject.Name, version); err != nil {
+				return restored, err
+			}
+
+			if version.Deleted {
+				if err = softDeleteVersion(ctx, srClient, subject.Name, version.Version); err != nil {
+					return restored, err
+				}
+			}
+		}
+
+		restored++
+	}
+
+	if subject.Config != nil {
+		if err = setSubjectConfig(ctx, srClient, subject.Name, subject.Config); err != nil {
+			return restored, err
+		}
+	}
+
+	if subject.Mode != "" {
+		err = setSubjectMode(ctx, srClient, subject.Name, subject.Mode)
+	} else {
+		err = deleteSubjectMode(ctx, srClient, subject.Name)
+	}
+	if err != nil {
+		return restored, err
+	}
+
+	if subject.Deleted && includeDeleted {
+		if err = softDeleteSubject(ctx, srClient, subject.Name); err != nil {
+			return restored, err
+		}
+	}
+
+	return restored, nil
+}
+
+// URL returns the URL of the schema registry service
+func (s *SchemaRegistry) URL() string {
+	return fmt.Sprintf("http://%s:%d", s.Name, s.Spec.Port)
+}
+
 func (s *SchemaRegistry) newClient() (*srclient.ClientWithResponses, error) {
-	server := fmt.Sprintf("http://%s:%d", s.Name, s.Spec.Port)
-	return srclient.NewClientWithResponses(server)
+	return srclient.NewClientWithResponses(s.URL())
 }
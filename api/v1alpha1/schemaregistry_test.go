@@ -0,0 +1,84 @@
+package v1alpha1
+
+import (
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     map[string]SchemaRegistryConfigValue
+		additional []corev1.EnvVar
+		err        error
+		message    string
+	}{
+		{
+			name:       "valid",
+			config:     map[string]SchemaRegistryConfigValue{"kafkastore.topic": {Value: "_schemas"}},
+			additional: []corev1.EnvVar{{Name: "SCHEMA_REGISTRY_LOG4J_ROOT_LOGLEVEL", Value: "WARN"}},
+		},
+		{
+			name:    "managed config",
+			config:  map[string]SchemaRegistryConfigValue{"listeners": {}, "schema.compatibility.level": {}},
+			err:     ErrManagedConfig,
+			message: "config is managed by the operator: listeners, schema.compatibility.level",
+		},
+		{
+			name: "managed additional config",
+			additional: []corev1.EnvVar{
+				{Name: "SCHEMA_REGISTRY_MODE_MUTABILITY", Value: "true"},
+				{Name: "SCHEMA_REGISTRY_MODE_MUTABILITY", Value: "false"},
+			},
+			err:     ErrManagedConfig,
+			message: "config is managed by the operator: SCHEMA_REGISTRY_MODE_MUTABILITY",
+		},
+		{
+			name:       "config set in additional config",
+			config:     map[string]SchemaRegistryConfigValue{"kafkastore.topic": {Value: "_schemas"}},
+			additional: []corev1.EnvVar{{Name: "SCHEMA_REGISTRY_KAFKASTORE_TOPIC", Value: "_other"}},
+			err:        ErrConflictingConfig,
+			message:    "config is also set in additionalConfig: kafkastore.topic",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sr := &SchemaRegistry{Spec: SchemaRegistrySpec{Config: tt.config, AdditionalConfig: tt.additional}}
+			err := sr.ValidateConfig()
+			if tt.err == nil {
+				if err != nil {
+					t.Fatalf("ValidateConfig() error = %v", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("ValidateConfig() error = %v, want %v", err, tt.err)
+			}
+
+			if err.Error() != tt.message {
+				t.Errorf("ValidateConfig() error = %q, want %q", err.Error(), tt.message)
+			}
+		})
+	}
+}
+
+func TestSetConfigValid(t *testing.T) {
+	sr := &SchemaRegistry{}
+	sr.SetConfigValid(ErrConflictingConfig)
+	condition := meta.FindStatusCondition(sr.Status.Conditions, SchemaRegistryConditionConfigValid)
+	if condition == nil || condition.Status != metav1.ConditionFalse || condition.Reason != SchemaRegistryReasonConflictingConfig {
+		t.Fatalf("SetConfigValid() condition = %v, want false with reason %s", condition, SchemaRegistryReasonConflictingConfig)
+	}
+
+	sr.SetConfigValid(nil)
+	condition = meta.FindStatusCondition(sr.Status.Conditions, SchemaRegistryConditionConfigValid)
+	if condition == nil || condition.Status != metav1.ConditionTrue || condition.Reason != SchemaRegistryReasonValid {
+		t.Fatalf("SetConfigValid() condition = %v, want true with reason %s", condition, SchemaRegistryReasonValid)
+	}
+}
@@ -26,10 +26,30 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 )
 
+const (
+	SchemaRegistryConditionAPIReachable          = "APIReachable"
+	SchemaRegistryConditionKafkaClusterReachable = "KafkaClusterReachable"
+	SchemaRegistryReasonReachable                = "Reachable"
+	SchemaRegistryReasonUnreachable              = "Unreachable"
+	SchemaRegistryReasonDeploymentNotReady       = "DeploymentNotReady"
+	SchemaRegistryConditionLeaderElected         = "LeaderElected"
+	SchemaRegistryReasonLeaderFound              = "LeaderFound"
+	SchemaRegistryReasonNoLeader                 = "NoLeader"
+	SchemaRegistryReasonMetricsDisabled          = "MetricsDisabled"
+	SchemaRegistryReasonMetricsUnreadable        = "MetricsUnreadable"
+	SchemaRegistryConditionConfigValid           = "ConfigValid"
+	SchemaRegistryReasonValid                    = "Valid"
+	SchemaRegistryReasonManagedConfig            = "ManagedConfig"
+	SchemaRegistryReasonConflictingConfig        = "ConflictingConfig"
+)
+
 // SchemaRegistrySpec defines the desired state of SchemaRegistry
 type SchemaRegistrySpec struct {
 	// Used to define the version of the schema registry
@@ -45,6 +65,17 @@ type SchemaRegistrySpec struct {
 	// Used to define the compatibility level of the schema registry, one of NONE (default), BACKWARD, BACKWARD_TRANSITIVE, FORWARD, FORWARD_TRANSITIVE, FULL, FULL_TRANSITIVE
 	CompatibilityLevel string `json:"compatibilityLevel,omitempty,oneOf=NONE,BACKWARD,BACKWARD_TRANSITIVE,FORWARD,FORWARD_TRANSITIVE,FULL,FULL_TRANSITIVE" default:"NONE"`
 
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	// Used to define if schemas should be normalized by default, default is false
+	Normalize bool `json:"normalize,omitempty" default:"false"`
+
+	// +kubebuilder:default:="READWRITE"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=READWRITE;READONLY;IMPORT
+	// Used to define the global mode of the schema registry, one of READWRITE (default), READONLY, IMPORT
+	Mode string `json:"mode,omitempty" default:"READWRITE"`
+
 	// +kubebuilder:default:=8082
 	// +kubebuilder:validation:Optional
 	// Used to define the port of the schema registry
@@ -65,6 +96,11 @@ type SchemaRegistrySpec struct {
 	// Used to define the metrics specifications of the schema registry, default is disabled
 	Metrics SchemaRegistryMetrics `json:"metrics,omitempty"`
 
+	// +kubebuilder:default:={}
+	// +kubebuilder:validation:Optional
+	// Used to define how subjects not owned by any Schema are handled, default is to report them
+	OrphanedSubjects SchemaRegistryOrphanedSubjects `json:"orphanedSubjects,omitempty"`
+
 	// Used to define the Kafka configuration
 	KafkaConfig KafkaConfig `json:"kafkaConfig"`
 
@@ -72,9 +108,206 @@ type SchemaRegistrySpec struct {
 	// Used to define the additional configurations as environmental variables for the schema registry
 	AdditionalConfig []corev1.EnvVar `json:"additionalConfig"`
 
+	// +kubebuilder:validation:Optional
+	// Used to define the schema registry properties, such as kafkastore.topic, which are converted to environmental
+	// variables. Properties managed by the operator are rejected
+	Config map[string]SchemaRegistryConfigValue `json:"config,omitempty"`
+
 	// +kubebuilder:default:=false
 	// Used to define the debug mode, default is disabled
 	Debug bool `json:"debug,omitempty" default:"false"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define the overrides of the pod template of the schema registry, merged with the operator's defaults
+	PodTemplate SchemaRegistryPodTemplate `json:"podTemplate,omitempty"`
+
+	// +kubebuilder:default:={}
+	// +kubebuilder:validation:Optional
+	// Used to define the availability of the schema registry when running more than one replica
+	Availability SchemaRegistryAvailability `json:"availability,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define the horizontal autoscaling of the schema registry, replicas is ignored when defined
+	Autoscaling *SchemaRegistryAutoscaling `json:"autoscaling,omitempty"`
+
+	// +kubebuilder:default:={}
+	// +kubebuilder:validation:Optional
+	// Used to define the read replicas of the schema registry, which are never eligible as leader, default is none
+	ReadReplicas SchemaRegistryReadReplicas `json:"readReplicas,omitempty"`
+}
+
+// SchemaRegistryReadReplicas defines the desired state of the read replicas, which are rendered as a separate
+// deployment behind the same service as the leader eligible replicas
+type SchemaRegistryReadReplicas struct {
+	// +kubebuilder:default:=0
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// Used to define the number of read replicas, default is 0
+	Replicas int32 `json:"replicas" default:"0"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define if a separate read-only service selecting only the read replicas is created
+	Service bool `json:"service,omitempty"`
+}
+
+// SchemaRegistryAutoscaling defines the desired state of the horizontal pod autoscaler
+// +kubebuilder:validation:XValidation:rule="!has(self.minReplicas) || self.minReplicas <= self.maxReplicas",message="minReplicas must not be greater than maxReplicas"
+type SchemaRegistryAutoscaling struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// Used to define the minimum number of replicas, default is 1
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// +kubebuilder:validation:Minimum=1
+	// Used to define the maximum number of replicas
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// Used to define the target average CPU utilization in percent of the requested CPU,
+	// default is 80 when no custom metric is defined
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define a custom metric to scale on, in addition to the CPU utilization when defined
+	Metric *autoscalingv2.MetricSpec `json:"metric,omitempty"`
+}
+
+const (
+	AvailabilitySpreadNone           = "None"
+	AvailabilitySpreadAntiAffinity   = "AntiAffinity"
+	AvailabilitySpreadTopologySpread = "TopologySpread"
+)
+
+// SchemaRegistryAvailability defines how the schema registry stays available during disruptions and rollouts,
+// the pod disruption budget and the spread of the pods only apply when running more than one replica
+type SchemaRegistryAvailability struct {
+	// +kubebuilder:default:={}
+	// +kubebuilder:validation:Optional
+	// Used to define the pod disruption budget of the schema registry, default is enabled
+	PodDisruptionBudget SchemaRegistryPodDisruptionBudget `json:"podDisruptionBudget,omitempty"`
+
+	// +kubebuilder:default:=AntiAffinity
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=None;AntiAffinity;TopologySpread
+	// Used to define how the pods are spread, one of None, AntiAffinity (default), TopologySpread.
+	// Ignored when the affinity or topology spread constraints are defined in the pod template
+	Spread string `json:"spread,omitempty" default:"AntiAffinity"`
+
+	// +kubebuilder:default:="kubernetes.io/hostname"
+	// +kubebuilder:validation:Optional
+	// Used to define the topology key the pods are spread over, default is kubernetes.io/hostname
+	TopologyKey string `json:"topologyKey,omitempty" default:"kubernetes.io/hostname"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define the update strategy of the deployment, default is a rolling update
+	Strategy *appsv1.DeploymentStrategy `json:"strategy,omitempty"`
+}
+
+// SchemaRegistryPodDisruptionBudget defines the desired state of the pod disruption budget
+// +kubebuilder:validation:XValidation:rule="!(has(self.minAvailable) && has(self.maxUnavailable))",message="minAvailable and maxUnavailable are mutually exclusive"
+type SchemaRegistryPodDisruptionBudget struct {
+	// +kubebuilder:default:=true
+	// +kubebuilder:validation:Optional
+	// Used to define if the pod disruption budget is enabled, default is enabled
+	Enabled bool `json:"enabled" default:"true"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define the minimum number of available pods
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define the maximum number of unavailable pods, default is 1 when minAvailable is not defined
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// SchemaRegistryConfigValue defines the value of a schema registry property
+// +kubebuilder:validation:XValidation:rule="!(has(self.value) && has(self.valueFrom))",message="value and valueFrom are mutually exclusive"
+type SchemaRegistryConfigValue struct {
+	// +kubebuilder:validation:Optional
+	// Used to define the value of the property
+	Value string `json:"value,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define the Secret or ConfigMap key the value of the property is read from
+	ValueFrom *SchemaRegistryConfigSource `json:"valueFrom,omitempty"`
+}
+
+// SchemaRegistryConfigSource defines the Secret or ConfigMap key the value of a schema registry property is read from
+// +kubebuilder:validation:XValidation:rule="has(self.secretKeyRef) != has(self.configMapKeyRef)",message="exactly one of secretKeyRef and configMapKeyRef is required"
+type SchemaRegistryConfigSource struct {
+	// +kubebuilder:validation:Optional
+	// Used to define the key of a Secret in the namespace of the schema registry
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define the key of a ConfigMap in the namespace of the schema registry
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+}
+
+// SchemaRegistryPodTemplate defines the overrides of the pod template of the schema registry.
+// Labels and annotations set by the operator take precedence over the ones defined here.
+type SchemaRegistryPodTemplate struct {
+	// +kubebuilder:validation:Optional
+	// Used to define the extra labels of the pods
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define the extra annotations of the pods
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define the node selector of the pods
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define the tolerations of the pods
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define the affinity of the pods
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define the topology spread constraints of the pods
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define the security context of the pods
+	SecurityContext *corev1.PodSecurityContext `json:"securityContext,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define the security context of the schema registry container
+	ContainerSecurityContext *corev1.SecurityContext `json:"containerSecurityContext,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define the name of the service account of the pods
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define the secrets used to pull the images of the pods
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define the priority class of the pods
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// Used to define the extra volumes of the pods
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define the extra volume mounts of the schema registry container
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// Used to define the sidecar containers of the pods
+	Sidecars []corev1.Container `json:"sidecars,omitempty"`
 }
 
 type ContainerImage struct {
@@ -117,6 +350,29 @@ type SchemaRegistryMetrics struct {
 	Port int32 `json:"port,omitempty"`
 }
 
+const (
+	OrphanedSubjectsPolicyReport = "Report"
+	OrphanedSubjectsPolicyPrune  = "Prune"
+)
+
+// SchemaRegistryOrphanedSubjects defines the handling of subjects not owned by any Schema
+type SchemaRegistryOrphanedSubjects struct {
+	// +kubebuilder:default:="Report"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Report;Prune
+	// Used to define the policy for orphaned subjects, one of Report (default), Prune
+	Policy string `json:"policy,omitempty" default:"Report"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define regular expressions of orphaned subjects which are never pruned
+	AllowList []string `json:"allowList,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define if pruned subjects are permanently deleted, default is false which soft deletes them,
+	// so they can still be recovered
+	Permanent bool `json:"permanent,omitempty"`
+}
+
 // KafkaConfig defines the desired state of the Kafka configuration
 type KafkaConfig struct {
 	// Used to define the Kafka bootstrap servers
@@ -145,13 +401,48 @@ type SchemaRegistryStatus struct {
 
 	// Used to define if the schema registry is ready
 	Ready bool `json:"ready"`
+
+	// Used to define the global compatibility level reported by the schema registry
+	CompatibilityLevel string `json:"compatibilityLevel,omitempty"`
+
+	// Used to define if schemas are normalized by default as reported by the schema registry
+	Normalize bool `json:"normalize,omitempty"`
+
+	// Used to define the global mode reported by the schema registry
+	Mode string `json:"mode,omitempty"`
+
+	// Used to define the subjects in the schema registry which are not owned by any Schema
+	OrphanedSubjects []string `json:"orphanedSubjects,omitempty"`
+
+	// Used to define if the reconciliation is paused by the paused annotation
+	Paused bool `json:"paused,omitempty"`
+
+	// Used to define the value of the latest handled force-reconcile annotation
+	LastForceReconcile string `json:"lastForceReconcile,omitempty"`
+
+	// Used to define the ID of the Kafka cluster backing the schema registry
+	KafkaClusterID string `json:"kafkaClusterId,omitempty"`
+
+	// Used to define the version of the running schema registry
+	Version string `json:"version,omitempty"`
+
+	// Used to define the name of the pod which is currently the leader, only reported when metrics are enabled,
+	// otherwise the LeaderElected condition is unknown
+	Leader string `json:"leader,omitempty"`
+
+	// Used to define the conditions of the schema registry
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Tag",type="string",JSONPath=".spec.image.tag",description="The tag of the schema registry"
 // +kubebuilder:printcolumn:name="Compatibility Level",type="string",JSONPath=".spec.compatibilityLevel",description="The compatibility level of the schema registry"
+// +kubebuilder:printcolumn:name="Mode",type="string",JSONPath=".status.mode",description="The global mode of the schema registry"
 // +kubebuilder:printcolumn:name="Replicas",type="integer",JSONPath=".spec.replicas",description="The number of Coherence Pods for this role"
+// +kubebuilder:printcolumn:name="Paused",type="boolean",JSONPath=".status.paused",description="If the reconciliation is paused"
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready",description="The readiness of the schema registry"
 
 // SchemaRegistry is the Schema for the schemaregistries API
@@ -0,0 +1,71 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// archiveTimeFormat is the format of the time an archive is taken at, which ends the archive name
+const archiveTimeFormat = "20060102-150405"
+
+// NextBackupTime returns when the next backup is due, or nil when a backup without a schedule has already been taken
+func (b *SchemaRegistryBackup) NextBackupTime() (*time.Time, error) {
+	last := b.CreationTimestamp.Time
+	if b.Status.LastBackupTime != nil {
+		last = b.Status.LastBackupTime.Time
+	}
+
+	if b.Spec.Schedule == "" {
+		if b.Status.LastBackupTime != nil {
+			return nil, nil
+		}
+
+		return &last, nil
+	}
+
+	schedule, err := cron.ParseStandard(b.Spec.Schedule)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSchedule, err)
+	}
+
+	next := schedule.Next(last)
+	return &next, nil
+}
+
+// ArchiveName returns the name of the archive taken at the given time
+func (b *SchemaRegistryBackup) ArchiveName(takenAt time.Time) string {
+	return b.Name + "-" + takenAt.UTC().Format(archiveTimeFormat)
+}
+
+// IsArchive returns true when the archive name is the name of an archive taken by the backup
+func (b *SchemaRegistryBackup) IsArchive(archiveName string) bool {
+	takenAt, found := strings.CutPrefix(archiveName, b.Name+"-")
+	if !found {
+		return false
+	}
+
+	_, err := time.Parse(archiveTimeFormat, takenAt)
+	return err == nil
+}
+
+// IsArchiveComplete returns true when the ConfigMaps hold every chunk of an archive, as an archive whose write
+// failed part way only has some of its chunks
+func IsArchiveComplete(configMaps []corev1.ConfigMap) bool {
+	indices := make(map[int]bool, len(configMaps))
+	chunkCount := 0
+	for _, configMap := range configMaps {
+		index, count, err := parseChunkAnnotation(configMap.Annotations[ArchiveChunkAnnotation])
+		if err != nil || (chunkCount != 0 && count != chunkCount) {
+			return false
+		}
+
+		indices[index] = true
+		chunkCount = count
+	}
+
+	return chunkCount > 0 && len(indices) == chunkCount
+}
@@ -0,0 +1,138 @@
+/*
+MIT License
+
+Copyright (c) 2025 Steffen Karlsson
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	BackupStorageConfigMap             = "ConfigMap"
+	BackupStoragePersistentVolumeClaim = "PersistentVolumeClaim"
+)
+
+// SchemaRegistryBackupSpec defines the desired state of SchemaRegistryBackup
+type SchemaRegistryBackupSpec struct {
+	// +kubebuilder:validation:Optional
+	// Used to define the cron schedule of the backup, when empty the backup is taken once
+	Schedule string `json:"schedule,omitempty"`
+
+	// +kubebuilder:default:=true
+	// +kubebuilder:validation:Optional
+	// Used to define if soft deleted subjects and versions are included in the backup, default is true
+	IncludeDeleted bool `json:"includeDeleted" default:"true"`
+
+	// +kubebuilder:default:=3
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// Used to define the number of archives to keep, default is 3
+	HistoryLimit int32 `json:"historyLimit" default:"3"`
+
+	// +kubebuilder:default:={}
+	// +kubebuilder:validation:Optional
+	// Used to define where the backup archives are stored, default is a set of ConfigMaps
+	Storage BackupStorage `json:"storage"`
+}
+
+// BackupStorage defines where the backup archives are stored
+// +kubebuilder:validation:XValidation:rule="self.type != 'PersistentVolumeClaim' || has(self.claimName)",message="claimName is required when type is PersistentVolumeClaim"
+type BackupStorage struct {
+	// +kubebuilder:default:="ConfigMap"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=ConfigMap;PersistentVolumeClaim
+	// Used to define the storage type, one of ConfigMap (default), PersistentVolumeClaim
+	Type string `json:"type" default:"ConfigMap"`
+
+	// +kubebuilder:validation:Optional
+	// Used to define the name of the PersistentVolumeClaim the archives are written to
+	ClaimName string `json:"claimName,omitempty"`
+}
+
+// SchemaRegistryBackupStatus defines the observed state of SchemaRegistryBackup
+type SchemaRegistryBackupStatus struct {
+	// Used to define the status message of the backup
+	Message string `json:"message,omitempty"`
+
+	// Used to define if the latest backup is completed
+	Ready bool `json:"ready"`
+
+	// Used to define the name of the latest archive
+	LatestArchive string `json:"latestArchive,omitempty"`
+
+	// Used to define the number of subjects in the latest archive
+	SubjectCount int `json:"subjectCount"`
+
+	// Used to define the number of versions in the latest archive
+	VersionCount int `json:"versionCount"`
+
+	// Used to define the checksum of the latest archive
+	Checksum string `json:"checksum,omitempty"`
+
+	// Used to define when the latest backup was taken
+	LastBackupTime *metav1.Time `json:"lastBackupTime,omitempty"`
+
+	// Used to define when the next scheduled backup is taken
+	NextBackupTime *metav1.Time `json:"nextBackupTime,omitempty"`
+
+	// Used to define the last transition time
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Schedule",type="string",JSONPath=".spec.schedule",description="The cron schedule of the backup"
+// +kubebuilder:printcolumn:name="Subjects",type="integer",JSONPath=".status.subjectCount",description="The number of subjects in the latest archive"
+// +kubebuilder:printcolumn:name="Versions",type="integer",JSONPath=".status.versionCount",description="The number of versions in the latest archive"
+// +kubebuilder:printcolumn:name="Last Backup",type="date",JSONPath=".status.lastBackupTime",description="When the latest backup was taken"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready",description="The readiness of the latest backup"
+
+// SchemaRegistryBackup is the Schema for the schemaregistrybackups API
+type SchemaRegistryBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SchemaRegistryBackupSpec   `json:"spec,omitempty"`
+	Status SchemaRegistryBackupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SchemaRegistryBackupList contains a list of SchemaRegistryBackup
+type SchemaRegistryBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SchemaRegistryBackup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SchemaRegistryBackup{}, &SchemaRegistryBackupList{})
+}
+
+// UpdateStatus updates the status of the backup
+func (b *SchemaRegistryBackup) UpdateStatus(ready bool, message string) {
+	b.Status.Ready = ready
+	b.Status.Message = message
+	b.Status.LastTransitionTime = metav1.Now()
+}
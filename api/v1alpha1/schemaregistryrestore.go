@@ -0,0 +1,112 @@
+package v1alpha1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/steffen-karlsson/schema-registry-operator/pkg/archive"
+)
+
+// LoadArchive reads the chunks of the archive from the ConfigMaps written by a SchemaRegistryBackup,
+// verifies the checksum and decodes it
+func (r *SchemaRegistryRestore) LoadArchive(ctx context.Context, reader client.Reader) (*archive.Archive, string, error) {
+	configMaps := &corev1.ConfigMapList{}
+	if err := reader.List(ctx, configMaps,
+		client.InNamespace(r.Namespace),
+		client.MatchingLabels{ArchiveLabelName: LabelValue(r.Spec.Archive)}); err != nil {
+		return nil, "", err
+	}
+
+	if len(configMaps.Items) == 0 {
+		return nil, "", fmt.Errorf("%w: %s", ErrArchiveNotFound, r.Spec.Archive)
+	}
+
+	chunks := make(map[int][]byte, len(configMaps.Items))
+	chunkCount := 0
+	checksum := ""
+	for _, configMap := range configMaps.Items {
+		index, count, err := parseChunkAnnotation(configMap.Annotations[ArchiveChunkAnnotation])
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: %s: %w", ErrArchiveNotFound, configMap.Name, err)
+		}
+
+		chunks[index] = configMap.BinaryData[ArchiveDataKey]
+		chunkCount = count
+		checksum = configMap.Annotations[ArchiveChecksumAnnotation]
+	}
+
+	if len(chunks) != chunkCount {
+		return nil, "", fmt.Errorf("%w: %s has %d of %d chunks", ErrArchiveNotFound, r.Spec.Archive, len(chunks), chunkCount)
+	}
+
+	indices := make([]int, 0, len(chunks))
+	for index := range chunks {
+		indices = append(indices, index)
+	}
+	sort.Ints(indices)
+
+	var data bytes.Buffer
+	for _, index := range indices {
+		data.Write(chunks[index])
+	}
+
+	if actual := archive.Checksum(data.Bytes()); actual != checksum {
+		return nil, "", fmt.Errorf("%w: expected %s, got %s", ErrArchiveChecksumMismatch, checksum, actual)
+	}
+
+	decoded, err := archive.Decode(data.Bytes())
+	if err != nil {
+		return nil, "", err
+	}
+
+	return decoded, checksum, nil
+}
+
+// VolumeBackup returns the SchemaRegistryBackup which wrote the archive to its PersistentVolumeClaim,
+// or nil when the archive is not written by a backup storing its archives in a PersistentVolumeClaim
+func (r *SchemaRegistryRestore) VolumeBackup(ctx context.Context, reader client.Reader) (*SchemaRegistryBackup, error) {
+	backups := &SchemaRegistryBackupList{}
+	if err := reader.List(ctx, backups, client.InNamespace(r.Namespace)); err != nil {
+		return nil, err
+	}
+
+	for i := range backups.Items {
+		backup := &backups.Items[i]
+		if backup.Spec.Storage.Type == BackupStoragePersistentVolumeClaim && backup.IsArchive(r.Spec.Archive) {
+			return backup, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// parseChunkAnnotation parses the chunk annotation in the format <index>/<count>
+func parseChunkAnnotation(annotation string) (int, int, error) {
+	index, count, found := strings.Cut(annotation, "/")
+	if !found {
+		return 0, 0, fmt.Errorf("invalid chunk annotation %q", annotation)
+	}
+
+	i, err := strconv.Atoi(index)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid chunk annotation %q: %w", annotation, err)
+	}
+
+	n, err := strconv.Atoi(count)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid chunk annotation %q: %w", annotation, err)
+	}
+
+	if i < 0 || i >= n {
+		return 0, 0, fmt.Errorf("invalid chunk annotation %q: index out of range", annotation)
+	}
+
+	return i, n, nil
+}
@@ -0,0 +1,66 @@
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func TestParseChunkAnnotation(t *testing.T) {
+	tests := []struct {
+		annotation string
+		index      int
+		count      int
+		valid      bool
+	}{
+		{annotation: "0/1", index: 0, count: 1, valid: true},
+		{annotation: "2/3", index: 2, count: 3, valid: true},
+		{annotation: "3/3"},
+		{annotation: "-1/3"},
+		{annotation: "0/0"},
+		{annotation: "1"},
+		{annotation: "a/3"},
+		{annotation: "1/b"},
+		{annotation: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.annotation, func(t *testing.T) {
+			index, count, err := parseChunkAnnotation(tt.annotation)
+			if !tt.valid {
+				if err == nil {
+					t.Errorf("parseChunkAnnotation(%q) should fail", tt.annotation)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("parseChunkAnnotation(%q) error = %v", tt.annotation, err)
+			}
+
+			if index != tt.index || count != tt.count {
+				t.Errorf("parseChunkAnnotation(%q) = %d, %d, want %d, %d", tt.annotation, index, count, tt.index, tt.count)
+			}
+		})
+	}
+}
+
+func TestLabelValue(t *testing.T) {
+	short := "backup-20250102-030405"
+	if LabelValue(short) != short {
+		t.Errorf("LabelValue(%q) = %q, want it unchanged", short, LabelValue(short))
+	}
+
+	long := strings.Repeat("a", 60) + "-20250102-030405"
+	other := strings.Repeat("a", 60) + "-20250102-030406"
+	for _, value := range []string{long, other} {
+		if errs := validation.IsValidLabelValue(LabelValue(value)); len(errs) > 0 {
+			t.Errorf("LabelValue(%q) = %q is not a valid label value: %v", value, LabelValue(value), errs)
+		}
+	}
+
+	if LabelValue(long) == LabelValue(other) {
+		t.Errorf("LabelValue() of different values should differ, both are %q", LabelValue(long))
+	}
+}
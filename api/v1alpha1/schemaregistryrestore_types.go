@@ -0,0 +1,125 @@
+/*
+MIT License
+
+Copyright (c) 2025 Steffen Karlsson
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SchemaRegistryRestoreSpec defines the desired state of SchemaRegistryRestore
+type SchemaRegistryRestoreSpec struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="archive is immutable"
+	// Used to define the name of the archive to restore, as reported by the SchemaRegistryBackup status,
+	// only archives stored in ConfigMaps can be restored
+	Archive string `json:"archive"`
+
+	// +kubebuilder:default:=true
+	// +kubebuilder:validation:Optional
+	// Used to define if soft deleted subjects and versions in the archive are restored, default is true
+	IncludeDeleted bool `json:"includeDeleted" default:"true"`
+}
+
+// SubjectRestoreStatus defines the restore progress of a single subject
+type SubjectRestoreStatus struct {
+	// Used to define the name of the subject
+	Name string `json:"name"`
+
+	// Used to define the number of versions of the subject in the archive
+	Versions int `json:"versions"`
+
+	// Used to define the number of versions of the subject restored so far
+	RestoredVersions int `json:"restoredVersions"`
+
+	// Used to define if the subject, including its config and mode, is restored
+	Restored bool `json:"restored"`
+
+	// Used to define the error message of the latest restore attempt of the subject
+	Message string `json:"message,omitempty"`
+}
+
+// SchemaRegistryRestoreStatus defines the observed state of SchemaRegistryRestore
+type SchemaRegistryRestoreStatus struct {
+	// Used to define the status message of the restore
+	Message string `json:"message,omitempty"`
+
+	// Used to define if the restore is completed
+	Ready bool `json:"ready"`
+
+	// Used to define the checksum of the archive being restored
+	Checksum string `json:"checksum,omitempty"`
+
+	// Used to define the number of subjects to restore
+	SubjectCount int `json:"subjectCount"`
+
+	// Used to define the number of subjects restored so far
+	RestoredSubjectCount int `json:"restoredSubjectCount"`
+
+	// Used to define the restore progress per subject
+	Subjects []SubjectRestoreStatus `json:"subjects,omitempty"`
+
+	// Used to define when the restore was completed
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Used to define the last transition time
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Archive",type="string",JSONPath=".spec.archive",description="The archive being restored"
+// +kubebuilder:printcolumn:name="Restored",type="integer",JSONPath=".status.restoredSubjectCount",description="The number of subjects restored"
+// +kubebuilder:printcolumn:name="Subjects",type="integer",JSONPath=".status.subjectCount",description="The number of subjects to restore"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready",description="The completion of the restore"
+
+// SchemaRegistryRestore is the Schema for the schemaregistryrestores API
+type SchemaRegistryRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SchemaRegistryRestoreSpec   `json:"spec,omitempty"`
+	Status SchemaRegistryRestoreStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SchemaRegistryRestoreList contains a list of SchemaRegistryRestore
+type SchemaRegistryRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SchemaRegistryRestore `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SchemaRegistryRestore{}, &SchemaRegistryRestoreList{})
+}
+
+// UpdateStatus updates the status of the restore
+func (r *SchemaRegistryRestore) UpdateStatus(ready bool, message string) {
+	r.Status.Ready = ready
+	r.Status.Message = message
+	r.Status.LastTransitionTime = metav1.Now()
+}
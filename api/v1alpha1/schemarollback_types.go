@@ -0,0 +1,117 @@
+/*
+MIT License
+
+Copyright (c) 2025 Steffen Karlsson
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	RollbackPolicyReregister  = "Reregister"
+	RollbackPolicyDeleteNewer = "DeleteNewer"
+)
+
+// SchemaRollbackSpec defines the desired state of SchemaRollback
+type SchemaRollbackSpec struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="schema is immutable"
+	// Used to define the name of the Schema to roll back, in the same namespace
+	Schema string `json:"schema"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="rollbackToVersion is immutable"
+	// Used to define the version to roll back to
+	RollbackToVersion int32 `json:"rollbackToVersion"`
+
+	// +kubebuilder:default:="Reregister"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Reregister;DeleteNewer
+	// Used to define how the rollback is performed, one of Reregister (default), which registers the content
+	// of the version as the new latest version, or DeleteNewer, which permanently deletes the newer versions
+	Policy string `json:"policy" default:"Reregister"`
+}
+
+// SchemaRollbackStatus defines the observed state of SchemaRollback
+type SchemaRollbackStatus struct {
+	// Used to define the status message of the rollback
+	Message string `json:"message,omitempty"`
+
+	// Used to define if the rollback is completed
+	Ready bool `json:"ready"`
+
+	// Used to define the latest version of the schema before the rollback
+	PreviousVersion int32 `json:"previousVersion,omitempty"`
+
+	// Used to define the latest version of the schema after the rollback
+	LatestVersion int32 `json:"latestVersion,omitempty"`
+
+	// Used to define the versions deleted by the rollback
+	DeletedVersions []int32 `json:"deletedVersions,omitempty"`
+
+	// Used to define when the rollback was completed
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Used to define the last transition time
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Schema",type="string",JSONPath=".spec.schema",description="The schema being rolled back"
+// +kubebuilder:printcolumn:name="To Version",type="integer",JSONPath=".spec.rollbackToVersion",description="The version rolled back to"
+// +kubebuilder:printcolumn:name="Policy",type="string",JSONPath=".spec.policy",description="How the rollback is performed"
+// +kubebuilder:printcolumn:name="Latest Version",type="integer",JSONPath=".status.latestVersion",description="The latest version after the rollback"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready",description="The completion of the rollback"
+
+// SchemaRollback is the Schema for the schemarollbacks API
+type SchemaRollback struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SchemaRollbackSpec   `json:"spec,omitempty"`
+	Status SchemaRollbackStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SchemaRollbackList contains a list of SchemaRollback
+type SchemaRollbackList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SchemaRollback `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SchemaRollback{}, &SchemaRollbackList{})
+}
+
+// UpdateStatus updates the status of the rollback
+func (r *SchemaRollback) UpdateStatus(ready bool, message string) {
+	r.Status.Ready = ready
+	r.Status.Message = message
+	r.Status.LastTransitionTime = metav1.Now()
+}
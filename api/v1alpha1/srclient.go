@@ -0,0 +1,493 @@
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"k8s.io/utils/ptr"
+
+	"github.com/steffen-karlsson/schema-registry-operator/pkg/archive"
+	"github.com/steffen-karlsson/schema-registry-operator/pkg/srclient"
+)
+
+func getGlobalConfig(ctx context.Context, srClient *srclient.ClientWithResponses) (*srclient.Config, error) {
+	resp, err := srClient.GetTopLevelConfig1WithResponse(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFailedToGetGlobalConfig, err)
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK || resp.ApplicationvndSchemaregistryV1JSON200 == nil {
+		return nil, fmt.Errorf("%w: %s", ErrFailedToGetGlobalConfig, resp.Status())
+	}
+
+	return resp.ApplicationvndSchemaregistryV1JSON200, nil
+}
+
+func getGlobalMode(ctx context.Context, srClient *srclient.ClientWithResponses) (*srclient.Mode, error) {
+	resp, err := srClient.GetTopLevelMode1WithResponse(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFailedToGetGlobalMode, err)
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK || resp.ApplicationvndSchemaregistryV1JSON200 == nil {
+		return nil, fmt.Errorf("%w: %s", ErrFailedToGetGlobalMode, resp.Status())
+	}
+
+	return resp.ApplicationvndSchemaregistryV1JSON200, nil
+}
+
+// serverClusterID is the response of the cluster ID endpoint, which is not described by the API specification
+type serverClusterID struct {
+	ID string `json:"id"`
+}
+
+// serverVersion is the response of the server version endpoint, which is not described by the API specification
+type serverVersion struct {
+	Version  string `json:"version"`
+	CommitID string `json:"commitId"`
+}
+
+// getClusterID returns the ID of the Kafka cluster backing the schema registry
+func getClusterID(ctx context.Context, srClient *srclient.ClientWithResponses) (string, error) {
+	resp, err := srClient.GetClusterId1WithResponse(ctx)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrFailedToGetClusterID, err)
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: %s", ErrFailedToGetClusterID, resp.Status())
+	}
+
+	clusterID := &serverClusterID{}
+	if err = json.Unmarshal(resp.Body, clusterID); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrFailedToGetClusterID, err)
+	}
+
+	return clusterID.ID, nil
+}
+
+// getServerVersion returns the version of the running schema registry
+func getServerVersion(ctx context.Context, srClient *srclient.ClientWithResponses) (*serverVersion, error) {
+	resp, err := srClient.GetSchemaRegistryVersion1WithResponse(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFailedToGetServerVersion, err)
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s", ErrFailedToGetServerVersion, resp.Status())
+	}
+
+	version := &serverVersion{}
+	if err = json.Unmarshal(resp.Body, version); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFailedToGetServerVersion, err)
+	}
+
+	return version, nil
+}
+
+// listSubjects returns the subjects of the schema registry, optionally including soft deleted subjects
+func listSubjects(ctx context.Context, srClient *srclient.ClientWithResponses, deleted bool) ([]string, error) {
+	resp, err := srClient.List1WithResponse(ctx, &srclient.List1Params{
+		Deleted: ptr.To(deleted),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFailedToListSubjects, err)
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK || resp.ApplicationvndSchemaregistryV1JSON200 == nil {
+		return nil, fmt.Errorf("%w: %s", ErrFailedToListSubjects, resp.Status())
+	}
+
+	return *resp.ApplicationvndSchemaregistryV1JSON200, nil
+}
+
+// listVersions returns the versions of a subject, optionally including soft deleted versions.
+// A subject which does not exist has no versions.
+func listVersions(
+	ctx context.Context,
+	srClient *srclient.ClientWithResponses,
+	subject string,
+	deleted bool,
+) ([]int32, error) {
+	resp, err := srClient.ListVersions1WithResponse(ctx, subject, &srclient.ListVersions1Params{
+		Deleted: ptr.To(deleted),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFailedToListVersions, err)
+	}
+
+	switch {
+	case resp.HTTPResponse.StatusCode == http.StatusNotFound:
+		return nil, nil
+	case resp.HTTPResponse.StatusCode != http.StatusOK || resp.ApplicationvndSchemaregistryV1JSON200 == nil:
+		return nil, fmt.Errorf("%w: %s", ErrFailedToListVersions, resp.Status())
+	}
+
+	return *resp.ApplicationvndSchemaregistryV1JSON200, nil
+}
+
+// getSchemaByVersion returns a single version of a subject, optionally looking up soft deleted versions
+func getSchemaByVersion(
+	ctx context.Context,
+	srClient *srclient.ClientWithResponses,
+	subject string,
+	version int32,
+	deleted bool,
+) (*srclient.Schema, error) {
+	resp, err := srClient.GetSchemaByVersion1WithResponse(ctx, subject, strconv.Itoa(int(version)),
+		&srclient.GetSchemaByVersion1Params{
+			Deleted: ptr.To(deleted),
+		})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFailedToGetSchema, err)
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK || resp.ApplicationvndSchemaregistryV1JSON200 == nil {
+		return nil, fmt.Errorf("%w: %s %d: %s", ErrFailedToGetSchema, subject, version, resp.Status())
+	}
+
+	return resp.ApplicationvndSchemaregistryV1JSON200, nil
+}
+
+// getSubjectConfig returns the subject level config, optionally falling back to the global config.
+// Without the fallback nil is returned when the subject uses the global config.
+func getSubjectConfig(
+	ctx context.Context,
+	srClient *srclient.ClientWithResponses,
+	subject string,
+	defaultToGlobal bool,
+) (*srclient.Config, error) {
+	resp, err := srClient.GetSubjectLevelConfig1WithResponse(ctx, subject, &srclient.GetSubjectLevelConfig1Params{
+		DefaultToGlobal: ptr.To(defaultToGlobal),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFailedToGetSubjectConfig, err)
+	}
+
+	switch resp.HTTPResponse.StatusCode {
+	case http.StatusOK:
+		return resp.ApplicationvndSchemaregistryV1JSON200, nil
+	case http.StatusNotFound:
+		return nil, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrFailedToGetSubjectConfig, resp.Status())
+}
+
+// getSubjectMode returns the subject level mode, or an empty string when the subject uses the global mode
+func getSubjectMode(
+	ctx context.Context,
+	srClient *srclient.ClientWithResponses,
+	subject string,
+) (string, error) {
+	resp, err := srClient.GetMode1WithResponse(ctx, subject, nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrFailedToGetSubjectMode, err)
+	}
+
+	switch resp.HTTPResponse.StatusCode {
+	case http.StatusOK:
+		if resp.ApplicationvndSchemaregistryV1JSON200 == nil {
+			return "", nil
+		}
+		return string(ptr.Deref(resp.ApplicationvndSchemaregistryV1JSON200.Mode, "")), nil
+	case http.StatusNotFound:
+		return "", nil
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrFailedToGetSubjectMode, resp.Status())
+}
+
+// setSubjectMode sets the subject level mode, forcing IMPORT since a subject may already contain versions
+func setSubjectMode(
+	ctx context.Context,
+	srClient *srclient.ClientWithResponses,
+	subject string,
+	mode string,
+) error {
+	resp, err := srClient.UpdateMode1WithResponse(ctx, subject, &srclient.UpdateMode1Params{
+		Force: ptr.To(mode == string(srclient.ModeUpdateRequestModeIMPORT)),
+	}, srclient.UpdateMode1JSONRequestBody{
+		Mode: ptr.To(srclient.ModeUpdateRequestMode(mode)),
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailedToSetSubjectMode, err)
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %s", ErrFailedToSetSubjectMode, resp.Status())
+	}
+
+	return nil
+}
+
+// deleteSubjectMode removes the subject level mode, so the subject falls back to the global mode
+func deleteSubjectMode(ctx context.Context, srClient *srclient.ClientWithResponses, subject string) error {
+	resp, err := srClient.DeleteSubjectMode1WithResponse(ctx, subject)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailedToSetSubjectMode, err)
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK && resp.HTTPResponse.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("%w: %s", ErrFailedToSetSubjectMode, resp.Status())
+	}
+
+	return nil
+}
+
+// setSubjectConfig sets the subject level config from a config read from a schema registry
+func setSubjectConfig(
+	ctx context.Context,
+	srClient *srclient.ClientWithResponses,
+	subject string,
+	config *srclient.Config,
+) error {
+	var compatibility *srclient.ConfigUpdateRequestCompatibility
+	if config.CompatibilityLevel != nil {
+		compatibility = ptr.To(srclient.ConfigUpdateRequestCompatibility(*config.CompatibilityLevel))
+	}
+
+	resp, err := srClient.UpdateSubjectLevelConfig1WithResponse(ctx, subject, srclient.UpdateSubjectLevelConfig1JSONRequestBody{
+		Alias:              config.Alias,
+		Compatibility:      compatibility,
+		CompatibilityGroup: config.CompatibilityGroup,
+		DefaultMetadata:    config.DefaultMetadata,
+		DefaultRuleSet:     config.DefaultRuleSet,
+		Normalize:          config.Normalize,
+		OverrideMetadata:   config.OverrideMetadata,
+		OverrideRuleSet:    config.OverrideRuleSet,
+		ValidateFields:     config.ValidateFields,
+		ValidateRules:      config.ValidateRules,
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailedToSetSubjectConfig, err)
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %s", ErrFailedToSetSubjectConfig, resp.Status())
+	}
+
+	return nil
+}
+
+// importVersion registers a version with its original schema ID and version number, which requires IMPORT mode
+func importVersion(
+	ctx context.Context,
+	srClient *srclient.ClientWithResponses,
+	subject string,
+	version *archive.Version,
+) error {
+	var references *[]srclient.SchemaReference
+	if len(version.References) > 0 {
+		references = &version.References
+	}
+
+	var schemaType *string
+	if version.SchemaType != "" {
+		schemaType = &version.SchemaType
+	}
+
+	resp, err := srClient.Register1WithResponse(ctx, subject, nil, srclient.Register1JSONRequestBody{
+		Id:         &version.ID,
+		Version:    &version.Version,
+		Schema:     &version.Schema,
+		SchemaType: schemaType,
+		References: references,
+		Metadata:   version.Metadata,
+		RuleSet:    version.RuleSet,
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailedToImportSchema, err)
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		message := resp.Status()
+		if errorMessage := errorMessageOf(resp.ApplicationvndSchemaregistryV1JSON409,
+			resp.ApplicationvndSchemaregistryV1JSON422); errorMessage != "" {
+			message = errorMessage
+		}
+
+		return fmt.Errorf("%w: %s %d: %s", ErrFailedToImportSchema, subject, version.Version, message)
+	}
+
+	return nil
+}
+
+// getReferencedBy returns the IDs of the schemas referencing a version of a subject.
+// A version which does not exist is not referenced.
+func getReferencedBy(
+	ctx context.Context,
+	srClient *srclient.ClientWithResponses,
+	subject string,
+	version int32,
+) ([]int32, error) {
+	resp, err := srClient.GetReferencedBy1WithResponse(ctx, subject, strconv.Itoa(int(version)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFailedToGetReferencedBy, err)
+	}
+
+	switch {
+	case resp.HTTPResponse.StatusCode == http.StatusNotFound:
+		return nil, nil
+	case resp.HTTPResponse.StatusCode != http.StatusOK || resp.ApplicationvndSchemaregistryV1JSON200 == nil:
+		return nil, fmt.Errorf("%w: %s %d: %s", ErrFailedToGetReferencedBy, subject, version, resp.Status())
+	}
+
+	return *resp.ApplicationvndSchemaregistryV1JSON200, nil
+}
+
+// getSubjectsByID returns the subjects a schema ID is registered under
+func getSubjectsByID(
+	ctx context.Context,
+	srClient *srclient.ClientWithResponses,
+	id int32,
+) ([]string, error) {
+	resp, err := srClient.GetSubjects1WithResponse(ctx, id, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFailedToListSubjects, err)
+	}
+
+	switch {
+	case resp.HTTPResponse.StatusCode == http.StatusNotFound:
+		return nil, nil
+	case resp.HTTPResponse.StatusCode != http.StatusOK || resp.ApplicationvndSchemaregistryV1JSON200 == nil:
+		return nil, fmt.Errorf("%w: schema %d: %s", ErrFailedToListSubjects, id, resp.Status())
+	}
+
+	return *resp.ApplicationvndSchemaregistryV1JSON200, nil
+}
+
+// softDeleteVersion soft deletes a single version of a subject
+func softDeleteVersion(
+	ctx context.Context,
+	srClient *srclient.ClientWithResponses,
+	subject string,
+	version int32,
+) error {
+	resp, err := srClient.DeleteSchemaVersion1WithResponse(ctx, subject, strconv.Itoa(int(version)), nil)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailedToSoftDeleteSchema, err)
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK && resp.HTTPResponse.StatusCode != http.StatusNotFound {
+		message := resp.Status()
+		if errorMessage := errorMessageOf(resp.ApplicationvndSchemaregistryV1JSON422); errorMessage != "" {
+			message = errorMessage
+		}
+
+		return fmt.Errorf("%w: %s %d: %s", ErrFailedToSoftDeleteSchema, subject, version, message)
+	}
+
+	return nil
+}
+
+// hardDeleteVersion permanently deletes a single version of a subject, which must be soft deleted first
+func hardDeleteVersion(
+	ctx context.Context,
+	srClient *srclient.ClientWithResponses,
+	subject string,
+	version int32,
+) error {
+	resp, err := srClient.DeleteSchemaVersion1WithResponse(ctx, subject, strconv.Itoa(int(version)),
+		&srclient.DeleteSchemaVersion1Params{
+			Permanent: ptr.To(true),
+		})
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailedToHardDeleteSchema, err)
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK && resp.HTTPResponse.StatusCode != http.StatusNotFound {
+		message := resp.Status()
+		if errorMessage := errorMessageOf(resp.ApplicationvndSchemaregistryV1JSON422); errorMessage != "" {
+			message = errorMessage
+		}
+
+		return fmt.Errorf("%w: %s %d: %s", ErrFailedToHardDeleteSchema, subject, version, message)
+	}
+
+	return nil
+}
+
+// softDeleteSubject soft deletes all versions of a subject
+func softDeleteSubject(ctx context.Context, srClient *srclient.ClientWithResponses, subject string) error {
+	resp, err := srClient.DeleteSubject1WithResponse(ctx, subject, nil)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailedToSoftDeleteSchema, err)
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK && resp.HTTPResponse.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("%w: %s: %s", ErrFailedToSoftDeleteSchema, subject, resp.Status())
+	}
+
+	return nil
+}
+
+// lookUpSchema returns the version of the subject the schema is registered as, or nil when it is not registered
+func lookUpSchema(
+	ctx context.Context,
+	srClient *srclient.ClientWithResponses,
+	subject string,
+	normalize bool,
+	request srclient.RegisterSchemaRequest,
+) (*srclient.Schema, error) {
+	resp, err := srClient.LookUpSchemaUnderSubject1WithResponse(ctx, subject, &srclient.LookUpSchemaUnderSubject1Params{
+		Normalize: ptr.To(normalize),
+	}, request)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFailedToLookUpSchema, err)
+	}
+
+	switch resp.HTTPResponse.StatusCode {
+	case http.StatusOK:
+		return resp.ApplicationvndSchemaregistryV1JSON200, nil
+	case http.StatusNotFound:
+		return nil, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrFailedToLookUpSchema, resp.Status())
+}
+
+// testCompatibility checks the schema against a version of the subject,
+// and returns nil when the subject or version does not exist
+func testCompatibility(
+	ctx context.Context,
+	srClient *srclient.ClientWithResponses,
+	subject string,
+	version string,
+	normalize bool,
+	request srclient.RegisterSchemaRequest,
+) (*srclient.CompatibilityCheckResponse, error) {
+	resp, err := srClient.TestCompatibilityBySubjectName1WithResponse(ctx, subject, version,
+		&srclient.TestCompatibilityBySubjectName1Params{
+			Normalize: ptr.To(normalize),
+			Verbose:   ptr.To(true),
+		}, request)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFailedToTestCompatibility, err)
+	}
+
+	switch resp.HTTPResponse.StatusCode {
+	case http.StatusOK:
+		return resp.ApplicationvndSchemaregistryV1JSON200, nil
+	case http.StatusNotFound:
+		return nil, nil
+	case http.StatusUnprocessableEntity:
+		return nil, NewInvalidSchemaOrTypeError(errorMessageOf(resp.ApplicationvndSchemaregistryV1JSON422))
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrFailedToTestCompatibility, resp.Status())
+}
+
+// errorMessageOf returns the message of the first error returned by the schema registry
+func errorMessageOf(errorMessages ...*srclient.ErrorMessage) string {
+	for _, errorMessage := range errorMessages {
+		if errorMessage != nil && errorMessage.Message != nil {
+			return *errorMessage.Message
+		}
+	}
+
+	return ""
+}
@@ -1,5 +1,44 @@
 package v1alpha1
 
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// +kubebuilder:object:generate=false
 type Updatable interface {
 	UpdateStatus(ready bool, message string)
 }
+
+// IsPaused returns true when the object is annotated to pause its reconciliation
+func IsPaused(meta metav1.ObjectMeta) bool {
+	return meta.Annotations[PausedAnnotation] == "true"
+}
+
+// ForceReconcileRequest returns the value of the force-reconcile annotation, a new value requests a reconciliation
+func ForceReconcileRequest(meta metav1.ObjectMeta) string {
+	return meta.Annotations[ForceReconcileAnnotation]
+}
+
+// IsForceDeleted returns true when the object is annotated to be deleted even though it is still referenced
+func IsForceDeleted(meta metav1.ObjectMeta) bool {
+	return meta.Annotations[ForceDeleteAnnotation] == "true"
+}
+
+// LabelValue returns the value shortened to the maximum length of a label value. Values which are too long are
+// truncated and suffixed with a hash of the full value, so different values remain different.
+func LabelValue(value string) string {
+	if len(value) <= validation.LabelValueMaxLength {
+		return value
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(value))
+	suffix := fmt.Sprintf("-%08x", h.Sum32())
+
+	return strings.TrimRight(value[:validation.LabelValueMaxLength-len(suffix)], "-_.") + suffix
+}
@@ -29,16 +29,35 @@ SOFTWARE.
 package v1alpha1
 
 import (
-	"k8s.io/api/core/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupStorage) DeepCopyInto(out *BackupStorage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupStorage.
+func (in *BackupStorage) DeepCopy() *BackupStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerImage) DeepCopyInto(out *ContainerImage) {
 	*out = *in
 	if in.PullPolicy != nil {
 		in, out := &in.PullPolicy, &out.PullPolicy
-		*out = new(v1.PullPolicy)
+		*out = new(corev1.PullPolicy)
 		**out = **in
 	}
 }
@@ -90,13 +109,101 @@ func (in *KafkaConfigAuthentication) DeepCopy() *KafkaConfigAuthentication {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MirrorBasicAuth) DeepCopyInto(out *MirrorBasicAuth) {
+	*out = *in
+	in.Username.DeepCopyInto(&out.Username)
+	in.Password.DeepCopyInto(&out.Password)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MirrorBasicAuth.
+func (in *MirrorBasicAuth) DeepCopy() *MirrorBasicAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(MirrorBasicAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MirrorEndpoint) DeepCopyInto(out *MirrorEndpoint) {
+	*out = *in
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(MirrorBasicAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(MirrorTLS)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MirrorEndpoint.
+func (in *MirrorEndpoint) DeepCopy() *MirrorEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(MirrorEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MirrorTLS) DeepCopyInto(out *MirrorTLS) {
+	*out = *in
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Certificate != nil {
+		in, out := &in.Certificate, &out.Certificate
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MirrorTLS.
+func (in *MirrorTLS) DeepCopy() *MirrorTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(MirrorTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromotionAuditEntry) DeepCopyInto(out *PromotionAuditEntry) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromotionAuditEntry.
+func (in *PromotionAuditEntry) DeepCopy() *PromotionAuditEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(PromotionAuditEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Schema) DeepCopyInto(out *Schema) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Schema.
@@ -117,6 +224,27 @@ func (in *Schema) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaHistoryEntry) DeepCopyInto(out *SchemaHistoryEntry) {
+	*out = *in
+	in.RegisteredAt.DeepCopyInto(&out.RegisteredAt)
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(SchemaRollbackRecord)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaHistoryEntry.
+func (in *SchemaHistoryEntry) DeepCopy() *SchemaHistoryEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaHistoryEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SchemaList) DeepCopyInto(out *SchemaList) {
 	*out = *in
@@ -150,26 +278,69 @@ func (in *SchemaList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SchemaRegistry) DeepCopyInto(out *SchemaRegistry) {
+func (in *SchemaMetadata) DeepCopyInto(out *SchemaMetadata) {
+	*out = *in
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Sensitive != nil {
+		in, out := &in.Sensitive, &out.Sensitive
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaMetadata.
+func (in *SchemaMetadata) DeepCopy() *SchemaMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaMirror) DeepCopyInto(out *SchemaMirror) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRegistry.
-func (in *SchemaRegistry) DeepCopy() *SchemaRegistry {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaMirror.
+func (in *SchemaMirror) DeepCopy() *SchemaMirror {
 	if in == nil {
 		return nil
 	}
-	out := new(SchemaRegistry)
+	out := new(SchemaMirror)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SchemaRegistry) DeepCopyObject() runtime.Object {
+func (in *SchemaMirror) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -177,66 +348,138 @@ func (in *SchemaRegistry) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SchemaRegistryIngress) DeepCopyInto(out *SchemaRegistryIngress) {
+func (in *SchemaMirrorList) DeepCopyInto(out *SchemaMirrorList) {
 	*out = *in
-	if in.Tls != nil {
-		in, out := &in.Tls, &out.Tls
-		*out = new(SchemaRegistryIngressTLS)
-		**out = **in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SchemaMirror, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRegistryIngress.
-func (in *SchemaRegistryIngress) DeepCopy() *SchemaRegistryIngress {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaMirrorList.
+func (in *SchemaMirrorList) DeepCopy() *SchemaMirrorList {
 	if in == nil {
 		return nil
 	}
-	out := new(SchemaRegistryIngress)
+	out := new(SchemaMirrorList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SchemaMirrorList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SchemaRegistryIngressTLS) DeepCopyInto(out *SchemaRegistryIngressTLS) {
+func (in *SchemaMirrorSpec) DeepCopyInto(out *SchemaMirrorSpec) {
 	*out = *in
+	in.Source.DeepCopyInto(&out.Source)
+	in.Destination.DeepCopyInto(&out.Destination)
+	out.Interval = in.Interval
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRegistryIngressTLS.
-func (in *SchemaRegistryIngressTLS) DeepCopy() *SchemaRegistryIngressTLS {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaMirrorSpec.
+func (in *SchemaMirrorSpec) DeepCopy() *SchemaMirrorSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SchemaRegistryIngressTLS)
+	out := new(SchemaMirrorSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SchemaRegistryList) DeepCopyInto(out *SchemaRegistryList) {
+func (in *SchemaMirrorStatus) DeepCopyInto(out *SchemaMirrorStatus) {
+	*out = *in
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]SubjectMirrorStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaMirrorStatus.
+func (in *SchemaMirrorStatus) DeepCopy() *SchemaMirrorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaMirrorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaPromotion) DeepCopyInto(out *SchemaPromotion) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaPromotion.
+func (in *SchemaPromotion) DeepCopy() *SchemaPromotion {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaPromotion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SchemaPromotion) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaPromotionList) DeepCopyInto(out *SchemaPromotionList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]SchemaRegistry, len(*in))
+		*out = make([]SchemaPromotion, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRegistryList.
-func (in *SchemaRegistryList) DeepCopy() *SchemaRegistryList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaPromotionList.
+func (in *SchemaPromotionList) DeepCopy() *SchemaPromotionList {
 	if in == nil {
 		return nil
 	}
-	out := new(SchemaRegistryList)
+	out := new(SchemaPromotionList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SchemaRegistryList) DeepCopyObject() runtime.Object {
+func (in *SchemaPromotionList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -244,85 +487,1005 @@ func (in *SchemaRegistryList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SchemaRegistryMetrics) DeepCopyInto(out *SchemaRegistryMetrics) {
+func (in *SchemaPromotionSpec) DeepCopyInto(out *SchemaPromotionSpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRegistryMetrics.
-func (in *SchemaRegistryMetrics) DeepCopy() *SchemaRegistryMetrics {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaPromotionSpec.
+func (in *SchemaPromotionSpec) DeepCopy() *SchemaPromotionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SchemaRegistryMetrics)
+	out := new(SchemaPromotionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SchemaRegistrySpec) DeepCopyInto(out *SchemaRegistrySpec) {
+func (in *SchemaPromotionStatus) DeepCopyInto(out *SchemaPromotionStatus) {
 	*out = *in
-	in.Image.DeepCopyInto(&out.Image)
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
+	if in.CompatibilityMessages != nil {
+		in, out := &in.CompatibilityMessages, &out.CompatibilityMessages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	in.Ingress.DeepCopyInto(&out.Ingress)
-	out.Metrics = in.Metrics
-	in.KafkaConfig.DeepCopyInto(&out.KafkaConfig)
+	if in.AuditTrail != nil {
+		in, out := &in.AuditTrail, &out.AuditTrail
+		*out = make([]PromotionAuditEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRegistrySpec.
-func (in *SchemaRegistrySpec) DeepCopy() *SchemaRegistrySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaPromotionStatus.
+func (in *SchemaPromotionStatus) DeepCopy() *SchemaPromotionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SchemaRegistrySpec)
+	out := new(SchemaPromotionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SchemaRegistryStatus) DeepCopyInto(out *SchemaRegistryStatus) {
+func (in *SchemaReference) DeepCopyInto(out *SchemaReference) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRegistryStatus.
-func (in *SchemaRegistryStatus) DeepCopy() *SchemaRegistryStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaReference.
+func (in *SchemaReference) DeepCopy() *SchemaReference {
 	if in == nil {
 		return nil
 	}
-	out := new(SchemaRegistryStatus)
+	out := new(SchemaReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SchemaSpec) DeepCopyInto(out *SchemaSpec) {
+func (in *SchemaRegistry) DeepCopyInto(out *SchemaRegistry) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaSpec.
-func (in *SchemaSpec) DeepCopy() *SchemaSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRegistry.
+func (in *SchemaRegistry) DeepCopy() *SchemaRegistry {
 	if in == nil {
 		return nil
 	}
-	out := new(SchemaSpec)
+	out := new(SchemaRegistry)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SchemaRegistry) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SchemaStatus) DeepCopyInto(out *SchemaStatus) {
+func (in *SchemaRegistryAutoscaling) DeepCopyInto(out *SchemaRegistryAutoscaling) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetCPUUtilizationPercentage != nil {
+		in, out := &in.TargetCPUUtilizationPercentage, &out.TargetCPUUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Metric != nil {
+		in, out := &in.Metric, &out.Metric
+		*out = new(v2.MetricSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRegistryAutoscaling.
+func (in *SchemaRegistryAutoscaling) DeepCopy() *SchemaRegistryAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaRegistryAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaRegistryAvailability) DeepCopyInto(out *SchemaRegistryAvailability) {
 	*out = *in
+	in.PodDisruptionBudget.DeepCopyInto(&out.PodDisruptionBudget)
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(appsv1.DeploymentStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaStatus.
-func (in *SchemaStatus) DeepCopy() *SchemaStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRegistryAvailability.
+func (in *SchemaRegistryAvailability) DeepCopy() *SchemaRegistryAvailability {
 	if in == nil {
 		return nil
 	}
-	out := new(SchemaStatus)
+	out := new(SchemaRegistryAvailability)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaRegistryBackup) DeepCopyInto(out *SchemaRegistryBackup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRegistryBackup.
+func (in *SchemaRegistryBackup) DeepCopy() *SchemaRegistryBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaRegistryBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SchemaRegistryBackup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaRegistryBackupList) DeepCopyInto(out *SchemaRegistryBackupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SchemaRegistryBackup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRegistryBackupList.
+func (in *SchemaRegistryBackupList) DeepCopy() *SchemaRegistryBackupList {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaRegistryBackupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SchemaRegistryBackupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaRegistryBackupSpec) DeepCopyInto(out *SchemaRegistryBackupSpec) {
+	*out = *in
+	out.Storage = in.Storage
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRegistryBackupSpec.
+func (in *SchemaRegistryBackupSpec) DeepCopy() *SchemaRegistryBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaRegistryBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaRegistryBackupStatus) DeepCopyInto(out *SchemaRegistryBackupStatus) {
+	*out = *in
+	if in.LastBackupTime != nil {
+		in, out := &in.LastBackupTime, &out.LastBackupTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextBackupTime != nil {
+		in, out := &in.NextBackupTime, &out.NextBackupTime
+		*out = (*in).DeepCopy()
+	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRegistryBackupStatus.
+func (in *SchemaRegistryBackupStatus) DeepCopy() *SchemaRegistryBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaRegistryBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaRegistryConfig) DeepCopyInto(out *SchemaRegistryConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRegistryConfig.
+func (in *SchemaRegistryConfig) DeepCopy() *SchemaRegistryConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaRegistryConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaRegistryConfigSource) DeepCopyInto(out *SchemaRegistryConfigSource) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRegistryConfigSource.
+func (in *SchemaRegistryConfigSource) DeepCopy() *SchemaRegistryConfigSource {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaRegistryConfigSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaRegistryConfigValue) DeepCopyInto(out *SchemaRegistryConfigValue) {
+	*out = *in
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(SchemaRegistryConfigSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRegistryConfigValue.
+func (in *SchemaRegistryConfigValue) DeepCopy() *SchemaRegistryConfigValue {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaRegistryConfigValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaRegistryIngress) DeepCopyInto(out *SchemaRegistryIngress) {
+	*out = *in
+	if in.Tls != nil {
+		in, out := &in.Tls, &out.Tls
+		*out = new(SchemaRegistryIngressTLS)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRegistryIngress.
+func (in *SchemaRegistryIngress) DeepCopy() *SchemaRegistryIngress {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaRegistryIngress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaRegistryIngressTLS) DeepCopyInto(out *SchemaRegistryIngressTLS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRegistryIngressTLS.
+func (in *SchemaRegistryIngressTLS) DeepCopy() *SchemaRegistryIngressTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaRegistryIngressTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaRegistryList) DeepCopyInto(out *SchemaRegistryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SchemaRegistry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRegistryList.
+func (in *SchemaRegistryList) DeepCopy() *SchemaRegistryList {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaRegistryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SchemaRegistryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaRegistryMetrics) DeepCopyInto(out *SchemaRegistryMetrics) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRegistryMetrics.
+func (in *SchemaRegistryMetrics) DeepCopy() *SchemaRegistryMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaRegistryMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaRegistryOrphanedSubjects) DeepCopyInto(out *SchemaRegistryOrphanedSubjects) {
+	*out = *in
+	if in.AllowList != nil {
+		in, out := &in.AllowList, &out.AllowList
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRegistryOrphanedSubjects.
+func (in *SchemaRegistryOrphanedSubjects) DeepCopy() *SchemaRegistryOrphanedSubjects {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaRegistryOrphanedSubjects)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaRegistryPodDisruptionBudget) DeepCopyInto(out *SchemaRegistryPodDisruptionBudget) {
+	*out = *in
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRegistryPodDisruptionBudget.
+func (in *SchemaRegistryPodDisruptionBudget) DeepCopy() *SchemaRegistryPodDisruptionBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaRegistryPodDisruptionBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaRegistryPodTemplate) DeepCopyInto(out *SchemaRegistryPodTemplate) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(corev1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContainerSecurityContext != nil {
+		in, out := &in.ContainerSecurityContext, &out.ContainerSecurityContext
+		*out = new(corev1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]corev1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]corev1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Sidecars != nil {
+		in, out := &in.Sidecars, &out.Sidecars
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRegistryPodTemplate.
+func (in *SchemaRegistryPodTemplate) DeepCopy() *SchemaRegistryPodTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaRegistryPodTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaRegistryReadReplicas) DeepCopyInto(out *SchemaRegistryReadReplicas) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRegistryReadReplicas.
+func (in *SchemaRegistryReadReplicas) DeepCopy() *SchemaRegistryReadReplicas {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaRegistryReadReplicas)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaRegistryRestore) DeepCopyInto(out *SchemaRegistryRestore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRegistryRestore.
+func (in *SchemaRegistryRestore) DeepCopy() *SchemaRegistryRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaRegistryRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SchemaRegistryRestore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaRegistryRestoreList) DeepCopyInto(out *SchemaRegistryRestoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SchemaRegistryRestore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRegistryRestoreList.
+func (in *SchemaRegistryRestoreList) DeepCopy() *SchemaRegistryRestoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaRegistryRestoreList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SchemaRegistryRestoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaRegistryRestoreSpec) DeepCopyInto(out *SchemaRegistryRestoreSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRegistryRestoreSpec.
+func (in *SchemaRegistryRestoreSpec) DeepCopy() *SchemaRegistryRestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaRegistryRestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaRegistryRestoreStatus) DeepCopyInto(out *SchemaRegistryRestoreStatus) {
+	*out = *in
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]SubjectRestoreStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRegistryRestoreStatus.
+func (in *SchemaRegistryRestoreStatus) DeepCopy() *SchemaRegistryRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaRegistryRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaRegistrySpec) DeepCopyInto(out *SchemaRegistrySpec) {
+	*out = *in
+	in.Image.DeepCopyInto(&out.Image)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Ingress.DeepCopyInto(&out.Ingress)
+	out.Metrics = in.Metrics
+	in.OrphanedSubjects.DeepCopyInto(&out.OrphanedSubjects)
+	in.KafkaConfig.DeepCopyInto(&out.KafkaConfig)
+	if in.AdditionalConfig != nil {
+		in, out := &in.AdditionalConfig, &out.AdditionalConfig
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]SchemaRegistryConfigValue, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	in.PodTemplate.DeepCopyInto(&out.PodTemplate)
+	in.Availability.DeepCopyInto(&out.Availability)
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(SchemaRegistryAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
+	out.ReadReplicas = in.ReadReplicas
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRegistrySpec.
+func (in *SchemaRegistrySpec) DeepCopy() *SchemaRegistrySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaRegistrySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaRegistryStatus) DeepCopyInto(out *SchemaRegistryStatus) {
+	*out = *in
+	if in.OrphanedSubjects != nil {
+		in, out := &in.OrphanedSubjects, &out.OrphanedSubjects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRegistryStatus.
+func (in *SchemaRegistryStatus) DeepCopy() *SchemaRegistryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaRegistryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaRetention) DeepCopyInto(out *SchemaRetention) {
+	*out = *in
+	if in.KeepLast != nil {
+		in, out := &in.KeepLast, &out.KeepLast
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRetention.
+func (in *SchemaRetention) DeepCopy() *SchemaRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaRollback) DeepCopyInto(out *SchemaRollback) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRollback.
+func (in *SchemaRollback) DeepCopy() *SchemaRollback {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaRollback)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SchemaRollback) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaRollbackList) DeepCopyInto(out *SchemaRollbackList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SchemaRollback, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRollbackList.
+func (in *SchemaRollbackList) DeepCopy() *SchemaRollbackList {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaRollbackList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SchemaRollbackList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaRollbackRecord) DeepCopyInto(out *SchemaRollbackRecord) {
+	*out = *in
+	if in.DeletedVersions != nil {
+		in, out := &in.DeletedVersions, &out.DeletedVersions
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRollbackRecord.
+func (in *SchemaRollbackRecord) DeepCopy() *SchemaRollbackRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaRollbackRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaRollbackSpec) DeepCopyInto(out *SchemaRollbackSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRollbackSpec.
+func (in *SchemaRollbackSpec) DeepCopy() *SchemaRollbackSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaRollbackSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaRollbackStatus) DeepCopyInto(out *SchemaRollbackStatus) {
+	*out = *in
+	if in.DeletedVersions != nil {
+		in, out := &in.DeletedVersions, &out.DeletedVersions
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaRollbackStatus.
+func (in *SchemaRollbackStatus) DeepCopy() *SchemaRollbackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaRollbackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaSpec) DeepCopyInto(out *SchemaSpec) {
+	*out = *in
+	if in.References != nil {
+		in, out := &in.References, &out.References
+		*out = make([]SchemaReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = new(SchemaMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = new(SchemaTargets)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(SchemaRetention)
+		(*in).DeepCopyInto(*out)
+	}
+	out.SchemaRegistryConfig = in.SchemaRegistryConfig
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaSpec.
+func (in *SchemaSpec) DeepCopy() *SchemaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaStatus) DeepCopyInto(out *SchemaStatus) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]SchemaTargetStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Registries != nil {
+		in, out := &in.Registries, &out.Registries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]SchemaHistoryEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaStatus.
+func (in *SchemaStatus) DeepCopy() *SchemaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaTargetStatus) DeepCopyInto(out *SchemaTargetStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaTargetStatus.
+func (in *SchemaTargetStatus) DeepCopy() *SchemaTargetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaTargetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaTargets) DeepCopyInto(out *SchemaTargets) {
+	*out = *in
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaTargets.
+func (in *SchemaTargets) DeepCopy() *SchemaTargets {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaTargets)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubjectMirrorStatus) DeepCopyInto(out *SubjectMirrorStatus) {
+	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubjectMirrorStatus.
+func (in *SubjectMirrorStatus) DeepCopy() *SubjectMirrorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SubjectMirrorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubjectRestoreStatus) DeepCopyInto(out *SubjectRestoreStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubjectRestoreStatus.
+func (in *SubjectRestoreStatus) DeepCopy() *SubjectRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SubjectRestoreStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -332,7 +1495,7 @@ func (in *ValueFrom) DeepCopyInto(out *ValueFrom) {
 	*out = *in
 	if in.Source != nil {
 		in, out := &in.Source, &out.Source
-		*out = new(v1.EnvVarSource)
+		*out = new(corev1.EnvVarSource)
 		(*in).DeepCopyInto(*out)
 	}
 }
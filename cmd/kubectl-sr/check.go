@@ -0,0 +1,112 @@
+/*
+MIT License
+
+Copyright (c) 2025 Steffen Karlsson
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/utils/ptr"
+
+	clientv1alpha1 "github.com/steffen-karlsson/schema-registry-operator/api/v1alpha1"
+	"github.com/steffen-karlsson/schema-registry-operator/pkg/srclient"
+)
+
+var errIncompatible = errors.New("schema is incompatible")
+
+func newCheckCommand(o *options) *cobra.Command {
+	var file, version string
+	cmd := &cobra.Command{
+		Use:   "check SCHEMA [-f FILE]",
+		Short: "Check the compatibility of a Schema, or a local schema file, against the schema registry",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.check(cmd.Context(), cmd.OutOrStdout(), args[0], file, version)
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "filename", "f", "", "A local schema file to check instead of the Schema content")
+	cmd.Flags().StringVar(&version, "version", clientv1alpha1.SchemaVersionLatest, "The registered version to check against")
+
+	return cmd
+}
+
+func (o *options) check(ctx context.Context, out io.Writer, name, file, version string) error {
+	schema, instance, err := o.getSchema(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	content := schema.Spec.Content
+	if file != "" {
+		local, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		content = string(local)
+	}
+
+	srClient, stop, err := o.connect(ctx, instance)
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	resp, err := srClient.TestCompatibilityBySubjectName1WithResponse(ctx, schema.GetSubject(), version,
+		&srclient.TestCompatibilityBySubjectName1Params{
+			Normalize: &schema.Spec.Normalize,
+			Verbose:   ptr.To(true),
+		}, srclient.TestCompatibilityBySubjectName1JSONRequestBody{
+			Schema:     &content,
+			SchemaType: &schema.Spec.Type,
+			References: schema.GetReferences(),
+		})
+	if err != nil {
+		return err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK || resp.ApplicationvndSchemaregistryV1JSON200 == nil {
+		return responseError(resp.Status(), resp.ApplicationvndSchemaregistryV1JSON404,
+			resp.ApplicationvndSchemaregistryV1JSON422)
+	}
+
+	result := resp.ApplicationvndSchemaregistryV1JSON200
+	if ptr.Deref(result.IsCompatible, false) {
+		_, _ = fmt.Fprintf(out, "%s is compatible with version %s of %s\n", name, version, schema.GetSubject())
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(out, "%s is incompatible with version %s of %s\n", name, version, schema.GetSubject())
+	for _, message := range ptr.Deref(result.Messages, nil) {
+		_, _ = fmt.Fprintln(out, "  "+message)
+	}
+
+	return errIncompatible
+}
@@ -0,0 +1,120 @@
+/*
+MIT License
+
+Copyright (c) 2025 Steffen Karlsson
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"k8s.io/utils/ptr"
+
+	clientv1alpha1 "github.com/steffen-karlsson/schema-registry-operator/api/v1alpha1"
+	"github.com/steffen-karlsson/schema-registry-operator/pkg/srclient"
+)
+
+func newDescribeCommand(o *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "describe SCHEMA",
+		Short: "Show the status of a Schema together with the config and mode of its subject",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.describe(cmd.Context(), cmd.OutOrStdout(), args[0])
+		},
+	}
+}
+
+func (o *options) describe(ctx context.Context, out io.Writer, name string) error {
+	schema, instance, err := o.getSchema(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	srClient, stop, err := o.connect(ctx, instance)
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	latest, err := getSchemaByVersion(ctx, srClient, schema.GetSubject(), clientv1alpha1.SchemaVersionLatest, false)
+	if err != nil {
+		return err
+	}
+
+	configResp, err := srClient.GetSubjectLevelConfig1WithResponse(ctx, schema.GetSubject(),
+		&srclient.GetSubjectLevelConfig1Params{
+			DefaultToGlobal: ptr.To(true),
+		})
+	if err != nil {
+		return err
+	}
+
+	if configResp.HTTPResponse.StatusCode != http.StatusOK || configResp.ApplicationvndSchemaregistryV1JSON200 == nil {
+		return responseError(configResp.Status(), configResp.ApplicationvndSchemaregistryV1JSON404)
+	}
+	config := configResp.ApplicationvndSchemaregistryV1JSON200
+
+	modeResp, err := srClient.GetMode1WithResponse(ctx, schema.GetSubject(), &srclient.GetMode1Params{
+		DefaultToGlobal: ptr.To(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	if modeResp.HTTPResponse.StatusCode != http.StatusOK || modeResp.ApplicationvndSchemaregistryV1JSON200 == nil {
+		return responseError(modeResp.Status(), modeResp.ApplicationvndSchemaregistryV1JSON404)
+	}
+	mode := modeResp.ApplicationvndSchemaregistryV1JSON200
+
+	writer := tabwriter.NewWriter(out, 0, 0, 1, ' ', 0)
+	_, _ = fmt.Fprintf(writer, "Name:\t%s\n", schema.Name)
+	_, _ = fmt.Fprintf(writer, "Namespace:\t%s\n", schema.Namespace)
+	_, _ = fmt.Fprintf(writer, "Schema Registry:\t%s\n", instance)
+	_, _ = fmt.Fprintf(writer, "Subject:\t%s\n", schema.GetSubject())
+	_, _ = fmt.Fprintf(writer, "Type:\t%s\n", schema.Spec.Type)
+	_, _ = fmt.Fprintf(writer, "Status:\t\n")
+	_, _ = fmt.Fprintf(writer, "  Ready:\t%t\n", schema.Status.Ready)
+	_, _ = fmt.Fprintf(writer, "  Message:\t%s\n", schema.Status.Message)
+	if schema.Status.SchemaRegistryError != "" {
+		_, _ = fmt.Fprintf(writer, "  Schema Registry Error:\t%s\n", schema.Status.SchemaRegistryError)
+	}
+	_, _ = fmt.Fprintf(writer, "  Latest Version:\t%d\n", schema.Status.LatestVersion)
+	_, _ = fmt.Fprintf(writer, "  Compatibility Level:\t%s\n", schema.Spec.CompatibilityLevel)
+	_, _ = fmt.Fprintf(writer, "Subject Status:\t\n")
+	if latest != nil {
+		_, _ = fmt.Fprintf(writer, "  Latest Version:\t%d\n", ptr.Deref(latest.Version, 0))
+		_, _ = fmt.Fprintf(writer, "  Schema ID:\t%d\n", ptr.Deref(latest.Id, 0))
+	} else {
+		_, _ = fmt.Fprintf(writer, "  Latest Version:\t<none>\n")
+	}
+	_, _ = fmt.Fprintf(writer, "  Compatibility Level:\t%s\n", ptr.Deref(config.CompatibilityLevel, ""))
+	_, _ = fmt.Fprintf(writer, "  Normalize:\t%t\n", ptr.Deref(config.Normalize, false))
+	_, _ = fmt.Fprintf(writer, "  Mode:\t%s\n", ptr.Deref(mode.Mode, ""))
+
+	return writer.Flush()
+}
@@ -0,0 +1,162 @@
+/*
+MIT License
+
+Copyright (c) 2025 Steffen Karlsson
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/utils/ptr"
+
+	clientv1alpha1 "github.com/steffen-karlsson/schema-registry-operator/api/v1alpha1"
+)
+
+var errDifferent = errors.New("schemas differ")
+
+func newDiffCommand(o *options) *cobra.Command {
+	var file, version string
+	cmd := &cobra.Command{
+		Use:   "diff SCHEMA -f FILE",
+		Short: "Compare a local schema file with the version registered for a Schema",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.diff(cmd.Context(), cmd.OutOrStdout(), args[0], file, version)
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "filename", "f", "", "The local schema file, such as an .avsc file")
+	cmd.Flags().StringVar(&version, "version", clientv1alpha1.SchemaVersionLatest, "The registered version to compare with")
+	_ = cmd.MarkFlagRequired("filename")
+
+	return cmd
+}
+
+func (o *options) diff(ctx context.Context, out io.Writer, name, file, version string) error {
+	local, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	schema, instance, err := o.getSchema(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	srClient, stop, err := o.connect(ctx, instance)
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	registered, err := getSchemaByVersion(ctx, srClient, schema.GetSubject(), version, true)
+	if err != nil {
+		return err
+	}
+
+	if registered == nil {
+		return fmt.Errorf("version %s of subject %s not found", version, schema.GetSubject())
+	}
+
+	registeredLabel := fmt.Sprintf("%s (version %d)", schema.GetSubject(), ptr.Deref(registered.Version, 0))
+	lines := diffLines(
+		strings.Split(formatSchema(ptr.Deref(registered.Schema, "")), "\n"),
+		strings.Split(formatSchema(string(local)), "\n"),
+	)
+	if lines == nil {
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(out, "--- %s\n+++ %s\n", registeredLabel, file)
+	for _, line := range lines {
+		_, _ = fmt.Fprintln(out, line)
+	}
+
+	return errDifferent
+}
+
+// formatSchema indents JSON based schemas, such as Avro and JSON schemas, so formatting differences are ignored
+func formatSchema(content string) string {
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, []byte(content)); err != nil {
+		return strings.TrimSpace(content)
+	}
+
+	var indented bytes.Buffer
+	_ = json.Indent(&indented, compacted.Bytes(), "", "  ")
+	return indented.String()
+}
+
+// diffLines returns the lines of a minimal line based diff from a to b, prefixed with " ", "-" or "+",
+// or nil when a and b are equal
+func diffLines(a, b []string) []string {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var lines []string
+	changed := false
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, " "+a[i])
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			// Removed lines are written before the added lines replacing them, as in a unified diff
+			lines = append(lines, "-"+a[i])
+			changed = true
+			i++
+		default:
+			lines = append(lines, "+"+b[j])
+			changed = true
+			j++
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+
+	return lines
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want []string
+	}{
+		{name: "equal", a: "a\nb\nc", b: "a\nb\nc"},
+		{name: "empty", a: "", b: ""},
+		{name: "added", a: "a\nc", b: "a\nb\nc", want: []string{" a", "+b", " c"}},
+		{name: "removed", a: "a\nb\nc", b: "a\nc", want: []string{" a", "-b", " c"}},
+		{name: "replaced", a: "a\nb\nc", b: "a\nx\nc", want: []string{" a", "-b", "+x", " c"}},
+		{name: "appended", a: "a", b: "a\nb", want: []string{" a", "+b"}},
+		{name: "all replaced", a: "a\nb", b: "x\ny", want: []string{"-a", "-b", "+x", "+y"}},
+		{
+			name: "moved",
+			a:    "a\nb\nc\nd",
+			b:    "b\nc\na\nd",
+			want: []string{"-a", " b", " c", "+a", " d"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diffLines(strings.Split(tt.a, "\n"), strings.Split(tt.b, "\n"))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("diffLines(%q, %q) = %q, want %q", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatSchema(t *testing.T) {
+	compact := `{"type":"record","name":"Order","fields":[{"name":"id","type":"string"}]}`
+	indented := `{
+	"type": "record",
+	"name": "Order",
+	"fields": [ { "name": "id", "type": "string" } ]
+}`
+
+	if formatSchema(compact) != formatSchema(indented) {
+		t.Errorf("formatSchema should ignore formatting differences, got %q and %q",
+			formatSchema(compact), formatSchema(indented))
+	}
+
+	protobuf := "\nsyntax = \"proto3\";\nmessage Order {}\n"
+	if got, want := formatSchema(protobuf), strings.TrimSpace(protobuf); got != want {
+		t.Errorf("formatSchema(%q) = %q, want %q", protobuf, got, want)
+	}
+}
@@ -0,0 +1,291 @@
+/*
+MIT License
+
+Copyright (c) 2025 Steffen Karlsson
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	clientv1alpha1 "github.com/steffen-karlsson/schema-registry-operator/api/v1alpha1"
+	"github.com/steffen-karlsson/schema-registry-operator/pkg/srclient"
+)
+
+const (
+	TargetKey   = "KEY"
+	TargetValue = "VALUE"
+
+	DefaultSchemaType = "AVRO"
+)
+
+var invalidNameCharacters = regexp.MustCompile(`[^a-z0-9-]+`)
+
+func newExportCommand(o *options) *cobra.Command {
+	var url, outputDir string
+	cmd := &cobra.Command{
+		Use:   "export SCHEMA_REGISTRY",
+		Short: "Export the subjects of a schema registry as Schema manifests bound to the SchemaRegistry",
+		Long: "Export the latest version of each subject ending with -key or -value as a Schema manifest.\n" +
+			"The manifests are written to stdout, or to a kustomize directory when --output-dir is set.\n" +
+			"By default the registry is reached through a port-forward to the SchemaRegistry Service,\n" +
+			"use --url to export a registry which is not deployed by the operator yet.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.export(cmd.Context(), cmd.OutOrStdout(), cmd.ErrOrStderr(), args[0], url, outputDir)
+		},
+	}
+
+	cmd.Flags().StringVar(&url, "url", "", "The URL of the schema registry, instead of a port-forward")
+	cmd.Flags().StringVarP(&outputDir, "output-dir", "o", "", "The directory to write a kustomization with the manifests to")
+
+	return cmd
+}
+
+func (o *options) export(ctx context.Context, out, errOut io.Writer, instance, url, outputDir string) error {
+	var srClient *srclient.ClientWithResponses
+	var err error
+	if url != "" {
+		srClient, err = srclient.NewClientWithResponses(url)
+	} else {
+		var stop func()
+		srClient, stop, err = o.connect(ctx, instance)
+		if stop != nil {
+			defer stop()
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	resp, err := srClient.List1WithResponse(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK || resp.ApplicationvndSchemaregistryV1JSON200 == nil {
+		return responseError(resp.Status(), resp.ApplicationvndSchemaregistryV1JSON500)
+	}
+
+	subjects := *resp.ApplicationvndSchemaregistryV1JSON200
+	sort.Strings(subjects)
+
+	manifests := map[string][]byte{}
+	for _, subject := range subjects {
+		schema, err := exportSchema(ctx, srClient, o.namespace, instance, subject)
+		if err != nil {
+			return err
+		}
+
+		if schema == nil {
+			_, _ = fmt.Fprintf(errOut, "Skipping subject %s, it does not end with -key or -value\n", subject)
+			continue
+		}
+
+		manifest, err := marshalSchema(schema)
+		if err != nil {
+			return err
+		}
+
+		manifests[schema.Name] = manifest
+	}
+
+	if outputDir == "" {
+		return writeManifests(out, manifests)
+	}
+
+	return writeKustomization(outputDir, manifests)
+}
+
+// exportSchema returns a Schema for the latest version of the subject, or nil when the subject
+// does not follow the <subject>-<target> naming of GetSubject
+func exportSchema(
+	ctx context.Context,
+	srClient *srclient.ClientWithResponses,
+	namespace string,
+	instance string,
+	subject string,
+) (*clientv1alpha1.Schema, error) {
+	name, target := splitSubject(subject)
+	if target == "" {
+		return nil, nil
+	}
+
+	latest, err := getSchemaByVersion(ctx, srClient, subject, clientv1alpha1.SchemaVersionLatest, false)
+	if err != nil || latest == nil {
+		return nil, err
+	}
+
+	configResp, err := srClient.GetSubjectLevelConfig1WithResponse(ctx, subject, &srclient.GetSubjectLevelConfig1Params{
+		DefaultToGlobal: ptr.To(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if configResp.HTTPResponse.StatusCode != http.StatusOK || configResp.ApplicationvndSchemaregistryV1JSON200 == nil {
+		return nil, responseError(configResp.Status(), configResp.ApplicationvndSchemaregistryV1JSON404)
+	}
+	config := configResp.ApplicationvndSchemaregistryV1JSON200
+
+	schema := &clientv1alpha1.Schema{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: clientv1alpha1.GroupVersion.String(),
+			Kind:       "Schema",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resourceName(subject),
+			Namespace: namespace,
+			Labels: map[string]string{
+				clientv1alpha1.SchemaRegistryLabelName: instance,
+			},
+		},
+		Spec: clientv1alpha1.SchemaSpec{
+			Subject:            name,
+			Target:             target,
+			Type:               ptr.Deref(latest.SchemaType, DefaultSchemaType),
+			Content:            ptr.Deref(latest.Schema, ""),
+			CompatibilityLevel: string(ptr.Deref(config.CompatibilityLevel, "")),
+			Normalize:          ptr.Deref(config.Normalize, false),
+		},
+	}
+
+	for _, reference := range ptr.Deref(latest.References, nil) {
+		schema.Spec.References = append(schema.Spec.References, clientv1alpha1.SchemaReference{
+			Name:    ptr.Deref(reference.Name, ""),
+			Subject: ptr.Deref(reference.Subject, ""),
+			Version: ptr.Deref(reference.Version, 0),
+		})
+	}
+
+	if latest.Metadata != nil {
+		schema.Spec.Metadata = &clientv1alpha1.SchemaMetadata{
+			Properties: ptr.Deref(latest.Metadata.Properties, nil),
+			Tags:       ptr.Deref(latest.Metadata.Tags, nil),
+			Sensitive:  ptr.Deref(latest.Metadata.Sensitive, nil),
+		}
+	}
+
+	return schema, nil
+}
+
+// splitSubject splits a subject into the subject and target of a Schema, the target is empty
+// when the subject does not end with -key or -value
+func splitSubject(subject string) (string, string) {
+	for _, target := range []string{TargetKey, TargetValue} {
+		if name, found := strings.CutSuffix(subject, "-"+strings.ToLower(target)); found && name != "" {
+			return name, target
+		}
+	}
+
+	return "", ""
+}
+
+// resourceName converts a subject to a valid name of a Kubernetes resource
+func resourceName(subject string) string {
+	name := invalidNameCharacters.ReplaceAllString(strings.ToLower(subject), "-")
+	return strings.Trim(name, "-")
+}
+
+// marshalSchema marshals the Schema without its status and server populated fields
+func marshalSchema(schema *clientv1alpha1.Schema) ([]byte, error) {
+	data, err := yaml.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := map[string]interface{}{}
+	if err = yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, err
+	}
+
+	delete(manifest, "status")
+	if metadata, ok := manifest["metadata"].(map[string]interface{}); ok {
+		delete(metadata, "creationTimestamp")
+	}
+
+	// The sync interval is left out, so the default of the CRD applies
+	if spec, ok := manifest["spec"].(map[string]interface{}); ok {
+		delete(spec, "schemaRegistryConfig")
+	}
+
+	return yaml.Marshal(manifest)
+}
+
+func sortedNames(manifests map[string][]byte) []string {
+	names := make([]string, 0, len(manifests))
+	for name := range manifests {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func writeManifests(out io.Writer, manifests map[string][]byte) error {
+	for i, name := range sortedNames(manifests) {
+		if i > 0 {
+			if _, err := fmt.Fprintln(out, "---"); err != nil {
+				return err
+			}
+		}
+
+		if _, err := out.Write(manifests[name]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeKustomization writes each manifest to its own file, and a kustomization.yaml listing all of them
+func writeKustomization(outputDir string, manifests map[string][]byte) error {
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return err
+	}
+
+	kustomization := strings.Builder{}
+	kustomization.WriteString("apiVersion: kustomize.config.k8s.io/v1beta1\nkind: Kustomization\nresources:\n")
+	for _, name := range sortedNames(manifests) {
+		file := name + ".yaml"
+		if err := os.WriteFile(filepath.Join(outputDir, file), manifests[name], 0o644); err != nil {
+			return err
+		}
+
+		kustomization.WriteString("- " + file + "\n")
+	}
+
+	return os.WriteFile(filepath.Join(outputDir, "kustomization.yaml"), []byte(kustomization.String()), 0o644)
+}
@@ -0,0 +1,172 @@
+/*
+MIT License
+
+Copyright (c) 2025 Steffen Karlsson
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clientv1alpha1 "github.com/steffen-karlsson/schema-registry-operator/api/v1alpha1"
+	"github.com/steffen-karlsson/schema-registry-operator/pkg/srclient"
+)
+
+const (
+	DriftNone     = "None"
+	DriftMissing  = "SubjectMissing"
+	DriftContent  = "ContentNotRegistered"
+	DriftOutdated = "NotLatest"
+	DriftUnknown  = "Unknown"
+)
+
+func newListCommand(o *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List Schemas with their version in the schema registry and drift",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.list(cmd.Context())
+		},
+	}
+}
+
+func (o *options) list(ctx context.Context) error {
+	schemas := &clientv1alpha1.SchemaList{}
+	if err := o.client.List(ctx, schemas, client.InNamespace(o.namespace)); err != nil {
+		return err
+	}
+
+	registries := newRegistryClients(o)
+	defer registries.close()
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "NAME\tSUBJECT\tREGISTRY\tVERSION\tREGISTRY VERSION\tDRIFT\tREADY")
+	for i := range schemas.Items {
+		schema := &schemas.Items[i]
+		instance, err := o.resolveInstance(ctx, schema)
+		if err != nil && !errors.Is(err, errNotBound) {
+			return err
+		}
+
+		registryVersion, drift := "-", DriftUnknown
+		if instance != "" {
+			srClient, err := registries.get(ctx, instance)
+			if err != nil {
+				return err
+			}
+
+			var latest int32
+			if latest, drift, err = detectDrift(ctx, srClient, schema); err != nil {
+				return err
+			}
+
+			if latest > 0 {
+				registryVersion = strconv.Itoa(int(latest))
+			}
+		}
+
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%d\t%s\t%s\t%t\n", schema.Name, schema.GetSubject(), instance,
+			schema.Status.LatestVersion, registryVersion, drift, schema.Status.Ready)
+	}
+
+	return writer.Flush()
+}
+
+// detectDrift compares the content of the Schema with the versions registered under its subject,
+// and returns the latest version in the schema registry with the kind of drift
+func detectDrift(
+	ctx context.Context,
+	srClient *srclient.ClientWithResponses,
+	schema *clientv1alpha1.Schema,
+) (int32, string, error) {
+	latest, err := getSchemaByVersion(ctx, srClient, schema.GetSubject(), clientv1alpha1.SchemaVersionLatest, false)
+	if err != nil {
+		return 0, "", err
+	}
+
+	if latest == nil {
+		return 0, DriftMissing, nil
+	}
+
+	resp, err := srClient.LookUpSchemaUnderSubject1WithResponse(ctx, schema.GetSubject(),
+		&srclient.LookUpSchemaUnderSubject1Params{
+			Normalize: &schema.Spec.Normalize,
+		}, srclient.LookUpSchemaUnderSubject1JSONRequestBody{
+			Schema:     &schema.Spec.Content,
+			SchemaType: &schema.Spec.Type,
+			References: schema.GetReferences(),
+		})
+	if err != nil {
+		return 0, "", err
+	}
+
+	latestVersion := ptr.Deref(latest.Version, 0)
+	switch resp.HTTPResponse.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return latestVersion, DriftContent, nil
+	default:
+		return 0, "", responseError(resp.Status(), resp.ApplicationvndSchemaregistryV1JSON404)
+	}
+
+	if ptr.Deref(resp.ApplicationvndSchemaregistryV1JSON200.Version, 0) != latestVersion {
+		return latestVersion, DriftOutdated, nil
+	}
+
+	return latestVersion, DriftNone, nil
+}
+
+// getSchemaByVersion returns a version of a subject, or nil when the subject or version does not exist
+func getSchemaByVersion(
+	ctx context.Context,
+	srClient *srclient.ClientWithResponses,
+	subject string,
+	version string,
+	deleted bool,
+) (*srclient.Schema, error) {
+	resp, err := srClient.GetSchemaByVersion1WithResponse(ctx, subject, version, &srclient.GetSchemaByVersion1Params{
+		Deleted: &deleted,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.HTTPResponse.StatusCode {
+	case http.StatusOK:
+		return resp.ApplicationvndSchemaregistryV1JSON200, nil
+	case http.StatusNotFound:
+		return nil, nil
+	}
+
+	return nil, responseError(resp.Status(), resp.ApplicationvndSchemaregistryV1JSON422)
+}
@@ -0,0 +1,175 @@
+/*
+MIT License
+
+Copyright (c) 2025 Steffen Karlsson
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clientv1alpha1 "github.com/steffen-karlsson/schema-registry-operator/api/v1alpha1"
+)
+
+var (
+	scheme = runtime.NewScheme()
+
+	errNotBound = errors.New("schema is not bound to a schema registry")
+)
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(clientv1alpha1.AddToScheme(scheme))
+}
+
+// options holds the flags shared by all commands and the clients created from them
+type options struct {
+	kubeconfig string
+	context    string
+	namespace  string
+	instance   string
+
+	restConfig *rest.Config
+	clientset  *kubernetes.Clientset
+	client     client.Client
+}
+
+func main() {
+	if err := newRootCommand().Execute(); err != nil {
+		// Differences and incompatibilities are already printed, and are only reflected in the exit code
+		if !errors.Is(err, errDifferent) && !errors.Is(err, errIncompatible) {
+			_, _ = fmt.Fprintln(os.Stderr, "Error:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+func newRootCommand() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:           "kubectl-sr",
+		Short:         "Inspect Schemas and the schema registries they are deployed to",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return o.complete()
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&o.kubeconfig, "kubeconfig", "", "Path to the kubeconfig file")
+	cmd.PersistentFlags().StringVar(&o.context, "context", "", "The kubeconfig context to use")
+	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "", "The namespace of the Schemas")
+	cmd.PersistentFlags().StringVar(&o.instance, "instance", "",
+		"The schema registry instance to inspect, defaults to the first instance the Schema targets")
+
+	cmd.AddCommand(
+		newListCommand(o),
+		newDiffCommand(o),
+		newCheckCommand(o),
+		newVersionsCommand(o),
+		newDescribeCommand(o),
+		newExportCommand(o),
+	)
+
+	return cmd
+}
+
+// complete creates the clients from the kubeconfig, and defaults the namespace to the one of the current context
+func (o *options) complete() error {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = o.kubeconfig
+	config := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{
+		CurrentContext: o.context,
+	})
+
+	var err error
+	if o.restConfig, err = config.ClientConfig(); err != nil {
+		return err
+	}
+
+	if o.namespace == "" {
+		if o.namespace, _, err = config.Namespace(); err != nil {
+			return err
+		}
+	}
+
+	if o.clientset, err = kubernetes.NewForConfig(o.restConfig); err != nil {
+		return err
+	}
+
+	o.client, err = client.New(o.restConfig, client.Options{Scheme: scheme})
+	return err
+}
+
+// getSchema returns the Schema with the given name and the name of the schema registry it is inspected in
+func (o *options) getSchema(ctx context.Context, name string) (*clientv1alpha1.Schema, string, error) {
+	schema := &clientv1alpha1.Schema{}
+	if err := o.client.Get(ctx, types.NamespacedName{Name: name, Namespace: o.namespace}, schema); err != nil {
+		return nil, "", err
+	}
+
+	instance, err := o.resolveInstance(ctx, schema)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return schema, instance, nil
+}
+
+// resolveInstance returns the schema registry instance given by the instance flag when the Schema targets it,
+// or otherwise the first instance the Schema targets, by its instance label or its targets
+func (o *options) resolveInstance(ctx context.Context, schema *clientv1alpha1.Schema) (string, error) {
+	targets, _, err := schema.ResolveTargets(ctx, o.client)
+	if err != nil {
+		return "", err
+	}
+
+	if o.instance != "" {
+		for _, target := range targets {
+			if target.Name == o.instance {
+				return target.Name, nil
+			}
+		}
+
+		return "", fmt.Errorf("%w: %s does not target %s", errNotBound, schema.Name, o.instance)
+	}
+
+	if len(targets) == 0 {
+		return "", fmt.Errorf("%w: %s has no %s label and no targets", errNotBound, schema.Name,
+			clientv1alpha1.SchemaRegistryLabelName)
+	}
+
+	return targets[0].Name, nil
+}
@@ -0,0 +1,188 @@
+/*
+MIT License
+
+Copyright (c) 2025 Steffen Karlsson
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clientv1alpha1 "github.com/steffen-karlsson/schema-registry-operator/api/v1alpha1"
+	"github.com/steffen-karlsson/schema-registry-operator/pkg/srclient"
+)
+
+var errNoReadyPod = errors.New("no ready pod found behind service")
+
+// connect port-forwards a random local port to a ready pod behind the Service the operator created for the
+// schema registry, and returns a client for it. The returned function stops the port-forward.
+func (o *options) connect(ctx context.Context, schemaRegistryName string) (*srclient.ClientWithResponses, func(), error) {
+	schemaRegistry := &clientv1alpha1.SchemaRegistry{}
+	name := types.NamespacedName{Name: schemaRegistryName, Namespace: o.namespace}
+	if err := o.client.Get(ctx, name, schemaRegistry); err != nil {
+		return nil, nil, err
+	}
+
+	service := &corev1.Service{}
+	if err := o.client.Get(ctx, name, service); err != nil {
+		return nil, nil, err
+	}
+
+	targetPort := int(schemaRegistry.Spec.Port)
+	for _, port := range service.Spec.Ports {
+		if port.Port == schemaRegistry.Spec.Port && port.TargetPort.IntValue() != 0 {
+			targetPort = port.TargetPort.IntValue()
+		}
+	}
+
+	pod, err := o.findReadyPod(ctx, service)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	transport, upgrader, err := spdy.RoundTripperFor(o.restConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	url := o.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(pod.Namespace).
+		Name(pod.Name).
+		SubResource("portforward").
+		URL()
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url)
+
+	stopCh := make(chan struct{})
+	readyCh := make(chan struct{})
+	forwarder, err := portforward.NewOnAddresses(dialer, []string{"127.0.0.1"},
+		[]string{fmt.Sprintf("0:%d", targetPort)}, stopCh, readyCh, io.Discard, os.Stderr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- forwarder.ForwardPorts()
+	}()
+
+	select {
+	case <-readyCh:
+	case err = <-errCh:
+		return nil, nil, fmt.Errorf("failed to port-forward to %s: %w", pod.Name, err)
+	case <-ctx.Done():
+		close(stopCh)
+		return nil, nil, ctx.Err()
+	}
+
+	ports, err := forwarder.GetPorts()
+	if err != nil {
+		close(stopCh)
+		return nil, nil, err
+	}
+
+	srClient, err := srclient.NewClientWithResponses(fmt.Sprintf("http://127.0.0.1:%d", ports[0].Local))
+	if err != nil {
+		close(stopCh)
+		return nil, nil, err
+	}
+
+	return srClient, func() { close(stopCh) }, nil
+}
+
+func (o *options) findReadyPod(ctx context.Context, service *corev1.Service) (*corev1.Pod, error) {
+	pods := &corev1.PodList{}
+	if err := o.client.List(ctx, pods,
+		client.InNamespace(service.Namespace),
+		client.MatchingLabels(service.Spec.Selector)); err != nil {
+		return nil, err
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+			continue
+		}
+
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+				return pod, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", errNoReadyPod, service.Name)
+}
+
+// registryClients port-forwards to each schema registry at most once
+type registryClients struct {
+	o       *options
+	clients map[string]*srclient.ClientWithResponses
+	stops   []func()
+}
+
+func newRegistryClients(o *options) *registryClients {
+	return &registryClients{o: o, clients: map[string]*srclient.ClientWithResponses{}}
+}
+
+func (r *registryClients) get(ctx context.Context, schemaRegistryName string) (*srclient.ClientWithResponses, error) {
+	if srClient, ok := r.clients[schemaRegistryName]; ok {
+		return srClient, nil
+	}
+
+	srClient, stop, err := r.o.connect(ctx, schemaRegistryName)
+	if err != nil {
+		return nil, err
+	}
+
+	r.clients[schemaRegistryName] = srClient
+	r.stops = append(r.stops, stop)
+	return srClient, nil
+}
+
+func (r *registryClients) close() {
+	for _, stop := range r.stops {
+		stop()
+	}
+}
+
+// responseError returns an error with the message returned by the schema registry, or the HTTP status
+func responseError(status string, errorMessages ...*srclient.ErrorMessage) error {
+	for _, errorMessage := range errorMessages {
+		if errorMessage != nil && errorMessage.Message != nil {
+			return fmt.Errorf("%s: %s", status, *errorMessage.Message)
+		}
+	}
+
+	return errors.New(status)
+}
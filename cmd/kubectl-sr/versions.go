@@ -0,0 +1,125 @@
+/*
+MIT License
+
+Copyright (c) 2025 Steffen Karlsson
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"k8s.io/utils/ptr"
+
+	"github.com/steffen-karlsson/schema-registry-operator/pkg/srclient"
+)
+
+func newVersionsCommand(o *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "versions SCHEMA",
+		Short: "Show the history of the subject of a Schema, including soft deleted versions",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.versions(cmd.Context(), cmd.OutOrStdout(), args[0])
+		},
+	}
+}
+
+func (o *options) versions(ctx context.Context, out io.Writer, name string) error {
+	schema, instance, err := o.getSchema(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	srClient, stop, err := o.connect(ctx, instance)
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	all, err := listVersions(ctx, srClient, schema.GetSubject(), true)
+	if err != nil {
+		return err
+	}
+
+	active, err := listVersions(ctx, srClient, schema.GetSubject(), false)
+	if err != nil {
+		return err
+	}
+
+	isActive := make(map[int32]bool, len(active))
+	for _, version := range active {
+		isActive[version] = true
+	}
+
+	writer := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "VERSION\tID\tTYPE\tSTATE")
+	for _, version := range all {
+		registered, err := getSchemaByVersion(ctx, srClient, schema.GetSubject(), strconv.Itoa(int(version)), true)
+		if err != nil {
+			return err
+		}
+
+		if registered == nil {
+			continue
+		}
+
+		state := "Active"
+		if !isActive[version] {
+			state = "SoftDeleted"
+		}
+
+		_, _ = fmt.Fprintf(writer, "%d\t%d\t%s\t%s\n", version, ptr.Deref(registered.Id, 0),
+			ptr.Deref(registered.SchemaType, "AVRO"), state)
+	}
+
+	return writer.Flush()
+}
+
+// listVersions returns the versions of a subject, optionally including soft deleted versions
+func listVersions(
+	ctx context.Context,
+	srClient *srclient.ClientWithResponses,
+	subject string,
+	deleted bool,
+) ([]int32, error) {
+	resp, err := srClient.ListVersions1WithResponse(ctx, subject, &srclient.ListVersions1Params{
+		Deleted: &deleted,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.HTTPResponse.StatusCode {
+	case http.StatusOK:
+		return ptr.Deref(resp.ApplicationvndSchemaregistryV1JSON200, nil), nil
+	case http.StatusNotFound:
+		return nil, nil
+	}
+
+	return nil, responseError(resp.Status(), resp.ApplicationvndSchemaregistryV1JSON500)
+}
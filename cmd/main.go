@@ -33,10 +33,14 @@ import (
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -129,8 +133,18 @@ func main() {
 		metricsServerOptions.FilterProvider = filters.WithAuthenticationAndAuthorization
 	}
 
+	// The purpose is to only cache the Secrets and ConfigMaps labeled to be watched, instead of every Secret and
+	// ConfigMap in the cluster, the others are read directly from the API server
+	watched := labels.SelectorFromSet(labels.Set{clientv1alpha1.WatchLabelName: clientv1alpha1.WatchLabelValue})
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme:                 scheme,
+		Scheme: scheme,
+		Cache: cache.Options{
+			ByObject: map[client.Object]cache.ByObject{
+				&corev1.Secret{}:    {Label: watched},
+				&corev1.ConfigMap{}: {Label: watched},
+			},
+		},
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
@@ -154,17 +168,64 @@ func main() {
 	}
 
 	if err = (&controller.SchemaRegistryReconciler{
-		Client: *k8s_manager.NewClient(mgr.GetClient()),
-		Scheme: mgr.GetScheme(),
+		Client:    *k8s_manager.NewClient(mgr.GetClient()),
+		Scheme:    mgr.GetScheme(),
+		Recorder:  mgr.GetEventRecorderFor("schemaregistry-controller"),
+		APIReader: mgr.GetAPIReader(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "SchemaRegistry")
 		os.Exit(1)
 	}
 	if err = (&controller.SchemaReconciler{
+		Client:   *k8s_manager.NewClient(mgr.GetClient()),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("schema-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Schema")
+		os.Exit(1)
+	}
+	if err = (&controller.SchemaRegistryBackupReconciler{
+		Client:    *k8s_manager.NewClient(mgr.GetClient()),
+		Scheme:    mgr.GetScheme(),
+		APIReader: mgr.GetAPIReader(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "SchemaRegistryBackup")
+		os.Exit(1)
+	}
+	podLogs, err := k8s_manager.NewPodLogReader(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create pod log reader")
+		os.Exit(1)
+	}
+	if err = (&controller.SchemaRegistryRestoreReconciler{
+		Client:    *k8s_manager.NewClient(mgr.GetClient()),
+		Scheme:    mgr.GetScheme(),
+		APIReader: mgr.GetAPIReader(),
+		PodLogs:   podLogs,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "SchemaRegistryRestore")
+		os.Exit(1)
+	}
+	if err = (&controller.SchemaMirrorReconciler{
+		Client:    *k8s_manager.NewClient(mgr.GetClient()),
+		Scheme:    mgr.GetScheme(),
+		APIReader: mgr.GetAPIReader(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "SchemaMirror")
+		os.Exit(1)
+	}
+	if err = (&controller.SchemaRollbackReconciler{
 		Client: *k8s_manager.NewClient(mgr.GetClient()),
 		Scheme: mgr.GetScheme(),
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Schema")
+		setupLog.Error(err, "unable to create controller", "controller", "SchemaRollback")
+		os.Exit(1)
+	}
+	if err = (&controller.SchemaPromotionReconciler{
+		Client: *k8s_manager.NewClient(mgr.GetClient()),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "SchemaPromotion")
 		os.Exit(1)
 	}
 	// +kubebuilder:scaffold:builder
@@ -0,0 +1,799 @@
+/*
+MIT License
+
+Copyright (c) 2025 Steffen Karlsson
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	clientv1alpha1 "github.com/steffen-karlsson/schema-registry-operator/api/v1alpha1"
+	k8s_manager "github.com/steffen-karlsson/schema-registry-operator/pkg/k8s"
+	"github.com/steffen-karlsson/schema-registry-operator/pkg/srclient"
+)
+
+const fakeRegistryContentType = "application/vnd.schemaregistry.v1+json"
+
+// fakeVersion is a single version of a subject in the fake schema registry
+type fakeVersion struct {
+	Version    int32
+	ID         int32
+	Schema     string
+	SchemaType string
+	References []srclient.SchemaReference
+	Metadata   *srclient.Metadata
+	Deleted    bool
+}
+
+// fakeSubject is a subject in the fake schema registry
+type fakeSubject struct {
+	versions      []*fakeVersion
+	deleted       bool
+	mode          string
+	compatibility string
+}
+
+// fakeRegistry is an in-memory schema registry implementing the part of the REST API used by the operator
+type fakeRegistry struct {
+	mu            sync.Mutex
+	subjects      map[string]*fakeSubject
+	nextID        int32
+	compatibility string
+	normalize     bool
+	mode          string
+	incompatible  map[string][]string
+	// configUpdates and modeUpdates count the updates of the global config and mode
+	configUpdates int
+	modeUpdates   int
+	// credentials are the basic authentication credentials required by the registry, in the format user:password
+	credentials string
+}
+
+// fakeRegistries serves a fake schema registry per host, and routes all requests of the default transport to them
+type fakeRegistries struct {
+	mu         sync.Mutex
+	server     *httptest.Server
+	transport  http.RoundTripper
+	registries map[string]*fakeRegistry
+}
+
+func newFakeRegistries() *fakeRegistries {
+	f := &fakeRegistries{registries: map[string]*fakeRegistry{}}
+	f.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.host(r.Host).serveHTTP(w, r)
+	}))
+
+	address := f.server.Listener.Addr().String()
+	f.transport = http.DefaultTransport
+	http.DefaultTransport = &http.Transport{
+		DialContext: func(ctx context.Context, network string, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, address)
+		},
+	}
+
+	return f
+}
+
+func (f *fakeRegistries) Close() {
+	http.DefaultTransport = f.transport
+	f.server.Close()
+}
+
+// registry returns the fake schema registry serving the SchemaRegistry
+func (f *fakeRegistries) registry(sr *clientv1alpha1.SchemaRegistry) *fakeRegistry {
+	return f.host(fmt.Sprintf("%s:%d", sr.Name, sr.Spec.Port))
+}
+
+func (f *fakeRegistries) host(host string) *fakeRegistry {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	registry, ok := f.registries[host]
+	if !ok {
+		registry = &fakeRegistry{
+			subjects:      map[string]*fakeSubject{},
+			nextID:        1,
+			compatibility: "BACKWARD",
+			mode:          "READWRITE",
+			incompatible:  map[string][]string{},
+		}
+		f.registries[host] = registry
+	}
+
+	return registry
+}
+
+// register registers a schema under the subject as a client would, and returns its version
+func (g *fakeRegistry) register(subject string, schema string, references ...srclient.SchemaReference) int32 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	version, _, _ := g.registerLocked(subject, srclient.RegisterSchemaRequest{
+		Schema:     ptr.To(schema),
+		References: ptr.To(references),
+	})
+	return version.Version
+}
+
+// softDelete soft deletes a version of the subject as a client would
+func (g *fakeRegistry) softDelete(subject string, version int32) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	_, _, _ = g.deleteVersionLocked(subject, strconv.Itoa(int(version)), false)
+}
+
+// activeVersions returns the versions of the subject which are not soft deleted
+func (g *fakeRegistry) activeVersions(subject string) []int32 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.versionsLocked(subject, false)
+}
+
+// version returns a version of the subject, including soft deleted versions
+func (g *fakeRegistry) version(subject string, version int32) *fakeVersion {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if s, ok := g.subjects[subject]; ok {
+		for _, v := range s.versions {
+			if v.Version == version {
+				copied := *v
+				return &copied
+			}
+		}
+	}
+
+	return nil
+}
+
+// subjectMode returns the subject level mode, which is empty when the subject uses the global mode
+func (g *fakeRegistry) subjectMode(subject string) string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if s, ok := g.subjects[subject]; ok {
+		return s.mode
+	}
+
+	return ""
+}
+
+// subjectCompatibility returns the subject level compatibility, which is empty when the subject uses the global one
+func (g *fakeRegistry) subjectCompatibility(subject string) string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if s, ok := g.subjects[subject]; ok {
+		return s.compatibility
+	}
+
+	return ""
+}
+
+func (g *fakeRegistry) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.credentials != "" {
+		username, password, _ := r.BasicAuth()
+		if username+":"+password != g.credentials {
+			writeFakeError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+	}
+
+	var path []string
+	for _, segment := range strings.Split(strings.Trim(r.URL.EscapedPath(), "/"), "/") {
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil {
+			writeFakeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		path = append(path, unescaped)
+	}
+
+	query := r.URL.Query()
+	deleted := query.Get("deleted") == "true"
+	permanent := query.Get("permanent") == "true"
+
+	switch {
+	case r.Method == http.MethodGet && match(path, "config"):
+		writeFakeJSON(w, srclient.Config{
+			CompatibilityLevel: ptr.To(srclient.ConfigCompatibilityLevel(g.compatibility)),
+			Normalize:          ptr.To(g.normalize),
+		})
+	case r.Method == http.MethodPut && match(path, "config"):
+		request := srclient.ConfigUpdateRequest{}
+		if !decodeFakeRequest(w, r, &request) {
+			return
+		}
+		if request.Compatibility != nil {
+			g.compatibility = string(*request.Compatibility)
+		}
+		g.normalize = ptr.Deref(request.Normalize, g.normalize)
+		g.configUpdates++
+		writeFakeJSON(w, request)
+	case r.Method == http.MethodGet && match(path, "mode"):
+		writeFakeJSON(w, srclient.Mode{Mode: ptr.To(srclient.ModeMode(g.mode))})
+	case r.Method == http.MethodPut && match(path, "mode"):
+		request := srclient.ModeUpdateRequest{}
+		if !decodeFakeRequest(w, r, &request) {
+			return
+		}
+		// The switch to IMPORT is only forced through while the registry contains schemas, as by the schema registry
+		mode := string(ptr.Deref(request.Mode, ""))
+		if mode == string(srclient.ModeUpdateRequestModeIMPORT) && len(g.subjects) > 0 && query.Get("force") != "true" {
+			writeFakeError(w, http.StatusUnprocessableEntity, "Cannot import since found existing subjects")
+			return
+		}
+		g.mode = mode
+		g.modeUpdates++
+		writeFakeJSON(w, request)
+	case r.Method == http.MethodGet && match(path, "v1", "metadata", "id"):
+		writeFakeJSON(w, map[string]string{"id": "fake-cluster"})
+	case r.Method == http.MethodGet && match(path, "v1", "metadata", "version"):
+		writeFakeJSON(w, map[string]string{"version": "7.7.0", "commitId": "fake"})
+	case r.Method == http.MethodGet && match(path, "subjects"):
+		writeFakeJSON(w, g.subjectsLocked(deleted))
+	case r.Method == http.MethodGet && match(path, "subjects", "*", "versions"):
+		versions := g.versionsLocked(path[1], deleted)
+		if len(versions) == 0 {
+			writeFakeError(w, http.StatusNotFound, "Subject '"+path[1]+"' not found.")
+			return
+		}
+		writeFakeJSON(w, versions)
+	case r.Method == http.MethodGet && match(path, "subjects", "*", "versions", "*"):
+		version := g.lookUpVersionLocked(path[1], path[3], deleted)
+		if version == nil {
+			writeFakeError(w, http.StatusNotFound, "Version "+path[3]+" not found.")
+			return
+		}
+		writeFakeJSON(w, fakeSchemaOf(path[1], version))
+	case r.Method == http.MethodPost && match(path, "subjects", "*", "versions"):
+		request := srclient.RegisterSchemaRequest{}
+		if !decodeFakeRequest(w, r, &request) {
+			return
+		}
+		version, status, message := g.registerLocked(path[1], request)
+		if status != http.StatusOK {
+			writeFakeError(w, status, message)
+			return
+		}
+		writeFakeJSON(w, srclient.RegisterSchemaResponse{Id: ptr.To(version.ID)})
+	case r.Method == http.MethodPost && match(path, "subjects", "*"):
+		request := srclient.RegisterSchemaRequest{}
+		if !decodeFakeRequest(w, r, &request) {
+			return
+		}
+		version := g.findLocked(path[1], request)
+		if version == nil {
+			writeFakeError(w, http.StatusNotFound, "Schema not found")
+			return
+		}
+		writeFakeJSON(w, fakeSchemaOf(path[1], version))
+	case r.Method == http.MethodDelete && match(path, "subjects", "*"):
+		versions, status, message := g.deleteSubjectLocked(path[1], permanent)
+		if status != http.StatusOK {
+			writeFakeError(w, status, message)
+			return
+		}
+		writeFakeJSON(w, versions)
+	case r.Method == http.MethodDelete && match(path, "subjects", "*", "versions", "*"):
+		version, status, message := g.deleteVersionLocked(path[1], path[3], permanent)
+		if status != http.StatusOK {
+			writeFakeError(w, status, message)
+			return
+		}
+		writeFakeJSON(w, version)
+	case r.Method == http.MethodGet && match(path, "subjects", "*", "versions", "*", "referencedby"):
+		version := g.lookUpVersionLocked(path[1], path[3], true)
+		if version == nil {
+			writeFakeError(w, http.StatusNotFound, "Version "+path[3]+" not found.")
+			return
+		}
+		writeFakeJSON(w, g.referencedByLocked(path[1], version.Version))
+	case r.Method == http.MethodGet && match(path, "schemas", "ids", "*", "subjects"):
+		writeFakeJSON(w, g.subjectsByIDLocked(path[2]))
+	case match(path, "config", "*"):
+		g.serveSubjectConfigLocked(w, r, path[1])
+	case match(path, "mode", "*"):
+		g.serveSubjectModeLocked(w, r, path[1])
+	case r.Method == http.MethodPost && match(path, "compatibility", "subjects", "*", "versions", "*"):
+		if len(g.versionsLocked(path[2], false)) == 0 {
+			writeFakeError(w, http.StatusNotFound, "Subject '"+path[2]+"' not found.")
+			return
+		}
+		messages := g.incompatible[path[2]]
+		writeFakeJSON(w, srclient.CompatibilityCheckResponse{
+			IsCompatible: ptr.To(len(messages) == 0),
+			Messages:     ptr.To(messages),
+		})
+	default:
+		writeFakeError(w, http.StatusNotFound, "unsupported request "+r.Method+" "+r.URL.Path)
+	}
+}
+
+func (g *fakeRegistry) serveSubjectConfigLocked(w http.ResponseWriter, r *http.Request, subject string) {
+	s := g.subjects[subject]
+	switch r.Method {
+	case http.MethodGet:
+		compatibility := ""
+		if s != nil {
+			compatibility = s.compatibility
+		}
+		if compatibility == "" && r.URL.Query().Get("defaultToGlobal") == "true" {
+			compatibility = g.compatibility
+		}
+		if compatibility == "" {
+			writeFakeError(w, http.StatusNotFound, "Subject '"+subject+"' does not have subject-level compatibility configured")
+			return
+		}
+		writeFakeJSON(w, srclient.Config{CompatibilityLevel: ptr.To(srclient.ConfigCompatibilityLevel(compatibility))})
+	case http.MethodPut:
+		request := srclient.ConfigUpdateRequest{}
+		if !decodeFakeRequest(w, r, &request) {
+			return
+		}
+		g.subjectLocked(subject).compatibility = string(ptr.Deref(request.Compatibility, ""))
+		writeFakeJSON(w, request)
+	case http.MethodDelete:
+		if s != nil {
+			s.compatibility = ""
+		}
+		writeFakeJSON(w, srclient.Config{})
+	}
+}
+
+func (g *fakeRegistry) serveSubjectModeLocked(w http.ResponseWriter, r *http.Request, subject string) {
+	s := g.subjects[subject]
+	switch r.Method {
+	case http.MethodGet:
+		if s == nil || s.mode == "" {
+			writeFakeError(w, http.StatusNotFound, "Subject '"+subject+"' does not have subject-level mode configured")
+			return
+		}
+		writeFakeJSON(w, srclient.Mode{Mode: ptr.To(srclient.ModeMode(s.mode))})
+	case http.MethodPut:
+		request := srclient.ModeUpdateRequest{}
+		if !decodeFakeRequest(w, r, &request) {
+			return
+		}
+		g.subjectLocked(subject).mode = string(ptr.Deref(request.Mode, ""))
+		writeFakeJSON(w, request)
+	case http.MethodDelete:
+		if s == nil || s.mode == "" {
+			writeFakeError(w, http.StatusNotFound, "Subject '"+subject+"' does not have subject-level mode configured")
+			return
+		}
+		s.mode = ""
+		writeFakeJSON(w, srclient.Mode{})
+	}
+}
+
+func (g *fakeRegistry) subjectLocked(subject string) *fakeSubject {
+	s, ok := g.subjects[subject]
+	if !ok {
+		s = &fakeSubject{}
+		g.subjects[subject] = s
+	}
+
+	return s
+}
+
+func (g *fakeRegistry) subjectsLocked(deleted bool) []string {
+	subjects := []string{}
+	for name, s := range g.subjects {
+		if len(s.versions) > 0 && (deleted || !s.deleted) {
+			subjects = append(subjects, name)
+		}
+	}
+	sort.Strings(subjects)
+
+	return subjects
+}
+
+func (g *fakeRegistry) versionsLocked(subject string, deleted bool) []int32 {
+	versions := []int32{}
+	if s, ok := g.subjects[subject]; ok {
+		for _, version := range s.versions {
+			if deleted || !version.Deleted {
+				versions = append(versions, version.Version)
+			}
+		}
+	}
+
+	return versions
+}
+
+func (g *fakeRegistry) lookUpVersionLocked(subject string, version string, deleted bool) *fakeVersion {
+	s, ok := g.subjects[subject]
+	if !ok {
+		return nil
+	}
+
+	if version == clientv1alpha1.SchemaVersionLatest {
+		for i := len(s.versions) - 1; i >= 0; i-- {
+			if !s.versions[i].Deleted {
+				return s.versions[i]
+			}
+		}
+		return nil
+	}
+
+	number, err := strconv.Atoi(version)
+	if err != nil {
+		return nil
+	}
+
+	for _, v := range s.versions {
+		if v.Version == int32(number) && (deleted || !v.Deleted) {
+			return v
+		}
+	}
+
+	return nil
+}
+
+func (g *fakeRegistry) findLocked(subject string, request srclient.RegisterSchemaRequest) *fakeVersion {
+	if s, ok := g.subjects[subject]; ok {
+		for _, version := range s.versions {
+			if !version.Deleted && sameFakeContent(version, request) {
+				return version
+			}
+		}
+	}
+
+	return nil
+}
+
+// registerLocked registers or, with an ID in IMPORT mode, imports a version, rejecting unknown references
+func (g *fakeRegistry) registerLocked(subject string, request srclient.RegisterSchemaRequest) (*fakeVersion, int, string) {
+	mode := g.mode
+	if s, ok := g.subjects[subject]; ok && s.mode != "" {
+		mode = s.mode
+	}
+
+	for _, reference := range ptr.Deref(request.References, nil) {
+		if g.lookUpVersionLocked(ptr.Deref(reference.Subject, ""), strconv.Itoa(int(ptr.Deref(reference.Version, 0))), false) == nil {
+			return nil, http.StatusUnprocessableEntity, fmt.Sprintf("Invalid schema, reference %s version %d not found",
+				ptr.Deref(reference.Subject, ""), ptr.Deref(reference.Version, 0))
+		}
+	}
+
+	imported := request.Id != nil
+	switch {
+	case imported && mode != string(srclient.ModeUpdateRequestModeIMPORT):
+		return nil, http.StatusUnprocessableEntity, "Subject " + subject + " is not in import mode"
+	case !imported && mode != "READWRITE":
+		return nil, http.StatusUnprocessableEntity, "Subject " + subject + " is in " + mode + " mode"
+	}
+
+	if !imported {
+		if existing := g.findLocked(subject, request); existing != nil {
+			return existing, http.StatusOK, ""
+		}
+
+		compatibility := g.compatibility
+		if s, ok := g.subjects[subject]; ok && s.compatibility != "" {
+			compatibility = s.compatibility
+		}
+
+		if messages := g.incompatible[subject]; len(messages) > 0 && compatibility != "NONE" &&
+			len(g.versionsLocked(subject, false)) > 0 {
+			return nil, http.StatusConflict, "Schema being registered is incompatible with an earlier schema: " +
+				strings.Join(messages, "; ")
+		}
+	}
+
+	s := g.subjectLocked(subject)
+	version := &fakeVersion{
+		Schema:     ptr.Deref(request.Schema, ""),
+		SchemaType: ptr.Deref(request.SchemaType, ""),
+		References: ptr.Deref(request.References, nil),
+		Metadata:   request.Metadata,
+	}
+
+	if imported {
+		version.ID = *request.Id
+		version.Version = ptr.Deref(request.Version, 1)
+		if slices.ContainsFunc(s.versions, func(v *fakeVersion) bool { return v.Version == version.Version }) {
+			return nil, http.StatusUnprocessableEntity, fmt.Sprintf("Version %d already exists", version.Version)
+		}
+		g.nextID = max(g.nextID, version.ID+1)
+	} else {
+		version.ID = g.idOfLocked(version)
+		version.Version = 1
+		if len(s.versions) > 0 {
+			version.Version = s.versions[len(s.versions)-1].Version + 1
+		}
+	}
+
+	s.deleted = false
+	s.versions = append(s.versions, version)
+	sort.Slice(s.versions, func(i, j int) bool {
+		return s.versions[i].Version < s.versions[j].Version
+	})
+
+	return version, http.StatusOK, ""
+}
+
+// idOfLocked returns the ID of identical content registered under any subject, or a new ID
+func (g *fakeRegistry) idOfLocked(version *fakeVersion) int32 {
+	for _, s := range g.subjects {
+		for _, v := range s.versions {
+			if v.Schema == version.Schema && v.SchemaType == version.SchemaType &&
+				fakeJSON(v.References) == fakeJSON(version.References) && fakeJSON(v.Metadata) == fakeJSON(version.Metadata) {
+				return v.ID
+			}
+		}
+	}
+
+	id := g.nextID
+	g.nextID++
+	return id
+}
+
+func (g *fakeRegistry) deleteSubjectLocked(subject string, permanent bool) ([]int32, int, string) {
+	s, ok := g.subjects[subject]
+	if !ok || len(s.versions) == 0 {
+		return nil, http.StatusNotFound, "Subject '" + subject + "' not found."
+	}
+
+	versions := g.versionsLocked(subject, true)
+	if permanent {
+		if !s.deleted {
+			return nil, http.StatusNotFound, "Subject '" + subject + "' was not deleted first before being permanently deleted"
+		}
+		delete(g.subjects, subject)
+		return versions, http.StatusOK, ""
+	}
+
+	for _, version := range s.versions {
+		if len(g.referencedByLocked(subject, version.Version)) > 0 {
+			return nil, http.StatusUnprocessableEntity, "One or more references exist to the schema"
+		}
+	}
+
+	s.deleted = true
+	for _, version := range s.versions {
+		version.Deleted = true
+	}
+
+	return versions, http.StatusOK, ""
+}
+
+func (g *fakeRegistry) deleteVersionLocked(subject string, version string, permanent bool) (int32, int, string) {
+	v := g.lookUpVersionLocked(subject, version, true)
+	if v == nil {
+		return 0, http.StatusNotFound, "Version " + version + " not found."
+	}
+
+	if len(g.referencedByLocked(subject, v.Version)) > 0 {
+		return 0, http.StatusUnprocessableEntity, "One or more references exist to the schema"
+	}
+
+	if !permanent {
+		v.Deleted = true
+		return v.Version, http.StatusOK, ""
+	}
+
+	if !v.Deleted {
+		return 0, http.StatusNotFound, "Version " + version + " was not deleted first before being permanently deleted"
+	}
+
+	s := g.subjects[subject]
+	s.versions = slices.DeleteFunc(s.versions, func(candidate *fakeVersion) bool { return candidate == v })
+	if len(s.versions) == 0 {
+		delete(g.subjects, subject)
+	}
+
+	return v.Version, http.StatusOK, ""
+}
+
+func (g *fakeRegistry) referencedByLocked(subject string, version int32) []int32 {
+	ids := []int32{}
+	for _, s := range g.subjects {
+		for _, v := range s.versions {
+			for _, reference := range v.References {
+				if ptr.Deref(reference.Subject, "") == subject && ptr.Deref(reference.Version, 0) == version &&
+					!slices.Contains(ids, v.ID) {
+					ids = append(ids, v.ID)
+				}
+			}
+		}
+	}
+
+	return ids
+}
+
+func (g *fakeRegistry) subjectsByIDLocked(id string) []string {
+	subjects := []string{}
+	for name, s := range g.subjects {
+		for _, v := range s.versions {
+			if strconv.Itoa(int(v.ID)) == id && !slices.Contains(subjects, name) {
+				subjects = append(subjects, name)
+			}
+		}
+	}
+	sort.Strings(subjects)
+
+	return subjects
+}
+
+func sameFakeContent(version *fakeVersion, request srclient.RegisterSchemaRequest) bool {
+	return version.Schema == ptr.Deref(request.Schema, "") &&
+		version.SchemaType == ptr.Deref(request.SchemaType, "") &&
+		fakeJSON(version.References) == fakeJSON(ptr.Deref(request.References, nil)) &&
+		fakeJSON(version.Metadata) == fakeJSON(request.Metadata)
+}
+
+func fakeSchemaOf(subject string, version *fakeVersion) srclient.Schema {
+	schema := srclient.Schema{
+		Subject:  ptr.To(subject),
+		Version:  ptr.To(version.Version),
+		Id:       ptr.To(version.ID),
+		Schema:   ptr.To(version.Schema),
+		Metadata: version.Metadata,
+	}
+	if version.SchemaType != "" {
+		schema.SchemaType = ptr.To(version.SchemaType)
+	}
+	if len(version.References) > 0 {
+		schema.References = ptr.To(version.References)
+	}
+
+	return schema
+}
+
+// fakeJSON serializes a value for comparison, treating empty slices and nil alike
+func fakeJSON(value any) string {
+	if references, ok := value.([]srclient.SchemaReference); ok && len(references) == 0 {
+		return "null"
+	}
+
+	data, _ := json.Marshal(value)
+	return string(data)
+}
+
+func match(path []string, pattern ...string) bool {
+	if len(path) != len(pattern) {
+		return false
+	}
+
+	for i := range pattern {
+		if pattern[i] != "*" && pattern[i] != path[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func decodeFakeRequest(w http.ResponseWriter, r *http.Request, request any) bool {
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		writeFakeError(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+
+	return true
+}
+
+func writeFakeJSON(w http.ResponseWriter, body any) {
+	w.Header().Set("Content-Type", fakeRegistryContentType)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeFakeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", fakeRegistryContentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(srclient.ErrorMessage{
+		ErrorCode: ptr.To(int32(status)),
+		Message:   ptr.To(message),
+	})
+}
+
+// newFakeClient creates a client backed by an in-memory object tracker, for tests which do not need an API server
+func newFakeClient(objects ...client.Object) *k8s_manager.Client {
+	return newInterceptedFakeClient(interceptor.Funcs{}, objects...)
+}
+
+// newInterceptedFakeClient returns a fake client whose calls can be intercepted, such as to make them fail
+func newInterceptedFakeClient(funcs interceptor.Funcs, objects ...client.Object) *k8s_manager.Client {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = clientv1alpha1.AddToScheme(scheme)
+
+	return k8s_manager.NewClient(fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		WithStatusSubresource(
+			&clientv1alpha1.Schema{},
+			&clientv1alpha1.SchemaRegistry{},
+			&clientv1alpha1.SchemaRegistryBackup{},
+			&clientv1alpha1.SchemaRegistryRestore{},
+			&clientv1alpha1.SchemaMirror{},
+			&clientv1alpha1.SchemaRollback{},
+			&clientv1alpha1.SchemaPromotion{},
+		).
+		WithInterceptorFuncs(funcs).
+		Build())
+}
+
+// newReadySchemaRegistry returns a SchemaRegistry reported as ready, served by the fake schema registries
+func newReadySchemaRegistry(name string) *clientv1alpha1.SchemaRegistry {
+	return &clientv1alpha1.SchemaRegistry{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: clientv1alpha1.SchemaRegistrySpec{
+			Port:               8081,
+			CompatibilityLevel: "BACKWARD",
+			// The defaults of the CRD are not applied by the fake client
+			Image: clientv1alpha1.ContainerImage{
+				PullPolicy: ptr.To(corev1.PullIfNotPresent),
+			},
+			Resources: &corev1.ResourceRequirements{},
+		},
+		Status: clientv1alpha1.SchemaRegistryStatus{
+			Ready: true,
+		},
+	}
+}
+
+// instanceLabels returns the labels binding an object to the SchemaRegistry instance
+func instanceLabels(name string) map[string]string {
+	return map[string]string{clientv1alpha1.SchemaRegistryLabelName: name}
+}
+
+// archiveConfigMaps returns the archive ConfigMaps in the namespace
+func archiveConfigMaps(ctx context.Context, c client.Client) []corev1.ConfigMap {
+	configMaps := &corev1.ConfigMapList{}
+	if err := c.List(ctx, configMaps, client.InNamespace("default"), client.HasLabels{clientv1alpha1.ArchiveLabelName}); err != nil {
+		return nil
+	}
+
+	return configMaps.Items
+}
@@ -28,32 +28,47 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	clientv1alpha1 "github.com/steffen-karlsson/schema-registry-operator/api/v1alpha1"
 	k8s_manager "github.com/steffen-karlsson/schema-registry-operator/pkg/k8s"
+	"github.com/steffen-karlsson/schema-registry-operator/pkg/srclient"
 )
 
 const (
 	SchemaDeployedSuccess = "Schema deployed successfully"
+	SchemaDryRunSuccess   = "Dry run completed, planned changes are recorded in the status"
+	SchemaDryRunRejected  = "Dry run completed, the Schema Registry would reject the schema"
+	SchemaDryRunDeletion  = "Dry run, the subject is kept until dry run is disabled"
+	SchemaPaused          = "Reconciliation is paused"
+	SchemaDeletionBlocked = "Deletion blocked, subject is still referenced by other subjects"
 )
 
 // SchemaReconciler reconciles a Schema object
 type SchemaReconciler struct {
 	k8s_manager.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 // +kubebuilder:rbac:groups=client.sroperator.io,resources=schemas,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=client.sroperator.io,resources=schemas/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=client.sroperator.io,resources=schemas/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -85,48 +100,119 @@ func (r *SchemaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		return ctrl.Result{}, err
 	}
 
-	// The purpose is to get the SchemaRegistry instance
-	schemaRegistry := &clientv1alpha1.SchemaRegistry{}
-	err = schemaRegistry.NewInstance(ctx, r, schema.ObjectMeta, schema)
-	switch {
-	case errors.Is(err, clientv1alpha1.ErrInstanceLabelNotFound) || errors.Is(err, clientv1alpha1.ErrInstanceNotFound):
-		logger.Info("schema registry instance not found")
+	// The purpose is to leave the subject untouched while the reconciliation is paused,
+	// including deletion, as the finalizer is only removed once the schema is resumed
+	if clientv1alpha1.IsPaused(schema.ObjectMeta) {
+		logger.Info("schema reconciliation is paused")
+		if schema.Status.Paused {
+			return ctrl.Result{}, nil
+		}
 
-		if err = r.Status().Update(ctx, schema); err != nil {
-			logger.Error(err, "failed to update schema status")
+		schema.Status.Paused = true
+		schema.Status.Message = SchemaPaused
+		return ctrl.Result{}, r.Status().Update(ctx, schema)
+	}
+	schema.Status.Paused = false
+
+	if request := clientv1alpha1.ForceReconcileRequest(schema.ObjectMeta); request != schema.Status.LastForceReconcile {
+		logger.Info("forced reconciliation requested", "Request", request)
+		schema.Status.LastForceReconcile = request
+	}
+
+	// The purpose is to get the SchemaRegistry instances the schema is registered to
+	targets, missing, err := schema.ResolveTargets(ctx, r)
+	if err != nil {
+		logger.Error(err, "failed to resolve schema registry instances")
+		return ctrl.Result{}, err
+	}
+
+	if schema.GetDeletionTimestamp() != nil {
+		// The subject is also deleted from the instances it is still registered in, but which are no longer targeted.
+		// The instances which no longer exist are skipped, as the subject is gone together with them.
+		untargeted, gone, err := schema.UntargetedRegistries(ctx, r, targets)
+		if err != nil {
+			logger.Error(err, "failed to get untargeted schema registry instances")
 			return ctrl.Result{}, err
 		}
 
-		return ctrl.Result{RequeueAfter: time.Minute}, nil
-	case err != nil:
-		logger.Error(err, "failed to get schema registry instance")
-		return ctrl.Result{}, err
+		if len(missing) > 0 || len(gone) > 0 {
+			logger.Info("schema registry instance not found, skipping", "Missing", missing, "Gone", gone)
+		}
+
+		return r.DeleteReconciler(ctx, schema, append(targets, untargeted...), logger)
 	}
 
-	schemaMarkedToBeDeleted := schema.GetDeletionTimestamp() != nil
-	if schemaMarkedToBeDeleted {
-		return r.DeleteReconciler(ctx, schema, schemaRegistry, logger)
+	if len(targets) == 0 {
+		return r.UntargetedReconciler(ctx, schema, missing, logger)
 	}
 
 	isNewSchemaObject := !controllerutil.ContainsFinalizer(schema, SchemaFinalizer)
 	if isNewSchemaObject {
-		return r.CreateReconciler(ctx, schema, schemaRegistry, logger)
+		return r.CreateReconciler(ctx, schema, targets, logger)
 	}
 
-	return r.UpdateReconciler(ctx, schema, schemaRegistry, logger)
+	return r.UpdateReconciler(ctx, schema, targets, missing, logger)
 
 }
 
 func (r *SchemaReconciler) DeleteReconciler(
 	ctx context.Context,
 	schema *clientv1alpha1.Schema,
-	schemaRegistry *clientv1alpha1.SchemaRegistry,
+	schemaRegistries []clientv1alpha1.SchemaRegistry,
 	logger logr.Logger,
 ) (ctrl.Result, error) {
 	logger.Info("Deleting Schema: ", "Name", schema.Name, "Namespace", schema.Namespace)
-	if err := schemaRegistry.DeleteSchema(ctx, schema, logger); err != nil {
-		logger.Error(err, "failed to delete schema")
-		return ctrl.Result{RequeueAfter: time.Minute}, err
+	if schema.Spec.DryRun {
+		// The subject is never deleted in dry run mode, so the finalizer is kept until dry run is disabled,
+		// unless there is no instance to delete the subject from
+		if len(schemaRegistries) > 0 {
+			return r.PlanDeletion(ctx, schema, schemaRegistries, logger)
+		}
+	} else {
+		// The purpose is to keep the subject, and the finalizer, until the paused instances are resumed
+		for i := range schemaRegistries {
+			if clientv1alpha1.IsPaused(schemaRegistries[i].ObjectMeta) {
+				logger.Info("schema registry reconciliation is paused", "SchemaRegistry", schemaRegistries[i].Name)
+				schema.UpdateStatus(false, "Schema Registry "+schemaRegistries[i].Name+" is paused")
+				if err := r.Status().Update(ctx, schema); err != nil {
+					logger.Error(err, "failed to update schema status")
+					return ctrl.Result{}, err
+				}
+
+				return ctrl.Result{RequeueAfter: time.Minute}, nil
+			}
+		}
+
+		// The purpose is to keep the subject, and the finalizer, while schemas of other subjects reference it,
+		// as those schemas can no longer be read by consumers once it is deleted
+		if !clientv1alpha1.IsForceDeleted(schema.ObjectMeta) {
+			var referencing []string
+			for i := range schemaRegistries {
+				subjects, err := schemaRegistries[i].FindReferencingSubjects(ctx, schema, logger)
+				if err != nil {
+					logger.Error(err, "failed to find referencing subjects", "SchemaRegistry", schemaRegistries[i].Name)
+					return ctrl.Result{RequeueAfter: time.Minute}, err
+				}
+
+				for _, subject := range subjects {
+					if len(schemaRegistries) > 1 {
+						subject = schemaRegistries[i].Name + "/" + subject
+					}
+					referencing = append(referencing, subject)
+				}
+			}
+
+			if len(referencing) > 0 {
+				return r.BlockDeletion(ctx, schema, referencing, logger)
+			}
+		}
+
+		for i := range schemaRegistries {
+			if err := schemaRegistries[i].DeleteSchema(ctx, schema, logger); err != nil {
+				logger.Error(err, "failed to delete schema", "SchemaRegistry", schemaRegistries[i].Name)
+				return ctrl.Result{RequeueAfter: time.Minute}, err
+			}
+		}
 	}
 
 	controllerutil.RemoveFinalizer(schema, SchemaFinalizer)
@@ -137,11 +223,71 @@ func (r *SchemaReconciler) DeleteReconciler(
 	return ctrl.Result{}, nil
 }
 
+// PlanDeletion records the deletion of the subject from each schema registry in the plan without deleting it
+func (r *SchemaReconciler) PlanDeletion(
+	ctx context.Context,
+	schema *clientv1alpha1.Schema,
+	schemaRegistries []clientv1alpha1.SchemaRegistry,
+	logger logr.Logger,
+) (ctrl.Result, error) {
+	logger.Info("Dry run, would delete subject", "Subject", schema.GetSubject())
+
+	var plan []string
+	for i := range schemaRegistries {
+		change := "would delete subject " + schema.GetSubject()
+		if len(schemaRegistries) > 1 {
+			change = schemaRegistries[i].Name + ": " + change
+		}
+		plan = append(plan, change)
+	}
+
+	schema.Status.Plan = plan
+	schema.Status.Targets = nil
+	schema.UpdateStatus(false, SchemaDryRunDeletion)
+
+	if err := r.Status().Update(ctx, schema); err != nil {
+		logger.Error(err, "failed to update schema status")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// BlockDeletion records in the status and as an event that the deletion is blocked by the referencing subjects
+func (r *SchemaReconciler) BlockDeletion(
+	ctx context.Context,
+	schema *clientv1alpha1.Schema,
+	referencing []string,
+	logger logr.Logger,
+) (ctrl.Result, error) {
+	logger.Info("schema deletion blocked, subject is still referenced", "ReferencedBy", referencing)
+
+	message := fmt.Sprintf("Subject %s is referenced by: %s, annotate with %s=true to delete it anyway",
+		schema.GetSubject(), strings.Join(referencing, ", "), clientv1alpha1.ForceDeleteAnnotation)
+	r.Recorder.Event(schema, corev1.EventTypeWarning, clientv1alpha1.SchemaConditionDeletionBlocked, message)
+
+	meta.SetStatusCondition(&schema.Status.Conditions, metav1.Condition{
+		Type:               clientv1alpha1.SchemaConditionDeletionBlocked,
+		Status:             metav1.ConditionTrue,
+		Reason:             clientv1alpha1.SchemaReasonReferenced,
+		Message:            message,
+		ObservedGeneration: schema.Generation,
+	})
+	schema.UpdateStatus(false, SchemaDeletionBlocked)
+
+	if err := r.Status().Update(ctx, schema); err != nil {
+		logger.Error(err, "failed to update schema status")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: time.Minute}, nil
+}
+
 // CreateReconciler creates a new schema in the schema registry
 func (r *SchemaReconciler) CreateReconciler(
 	ctx context.Context,
 	schema *clientv1alpha1.Schema,
-	schemaRegistry *clientv1alpha1.SchemaRegistry,
+	schemaRegistries []clientv1alpha1.SchemaRegistry,
 	logger logr.Logger,
 ) (ctrl.Result, error) {
 	logger.Info("Creating Schema: ", "Name", schema.Name, "Namespace", schema.Namespace)
@@ -151,25 +297,27 @@ func (r *SchemaReconciler) CreateReconciler(
 		schema.Spec.Subject = schema.Name
 	}
 
-	unique, err := schema.IsSubjectUnique(ctx, r, schemaRegistry.Name)
-	if err != nil {
-		logger.Error(err, "failed to check if subject is unique")
-		return ctrl.Result{}, err
-	}
+	for i := range schemaRegistries {
+		unique, err := schema.IsSubjectUnique(ctx, r, &schemaRegistries[i])
+		if err != nil {
+			logger.Error(err, "failed to check if subject is unique")
+			return ctrl.Result{}, err
+		}
 
-	if !unique {
-		logger.Info("subject is not unique")
+		if !unique {
+			logger.Info("subject is not unique")
 
-		message := fmt.Sprintf("Subject %s is not unique in Schema Registry %s",
-			schema.GetSubject(), schemaRegistry.Name)
-		schema.UpdateStatus(false, message)
+			message := fmt.Sprintf("Subject %s is not unique in Schema Registry %s",
+				schema.GetSubject(), schemaRegistries[i].Name)
+			schema.UpdateStatus(false, message)
 
-		if err = r.Status().Update(ctx, schema); err != nil {
-			logger.Error(err, "failed to update schema")
-			return ctrl.Result{}, err
-		}
+			if err = r.Status().Update(ctx, schema); err != nil {
+				logger.Error(err, "failed to update schema")
+				return ctrl.Result{}, err
+			}
 
-		return ctrl.Result{}, nil
+			return ctrl.Result{}, nil
+		}
 	}
 
 	if err := r.Update(ctx, schema); err != nil {
@@ -179,48 +327,291 @@ func (r *SchemaReconciler) CreateReconciler(
 	return ctrl.Result{}, nil
 }
 
-// UpdateReconciler updates the schema in the schema registry
+// UntargetedReconciler reports that no schema registry instance is targeted, and deletes the subject from the
+// instances it is still registered in, as the subject is no longer deployed to them
+func (r *SchemaReconciler) UntargetedReconciler(
+	ctx context.Context,
+	schema *clientv1alpha1.Schema,
+	missing []string,
+	logger logr.Logger,
+) (ctrl.Result, error) {
+	logger.Info("schema registry instance not found", "Missing", missing)
+	schema.Status.Targets = nil
+
+	// The subject is never deleted in dry run mode
+	var failed []string
+	if !schema.Spec.DryRun {
+		var err error
+		if failed, err = r.deleteUntargeted(ctx, schema, nil, logger); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	for _, name := range missing {
+		schema.Status.Targets = append(schema.Status.Targets, clientv1alpha1.SchemaTargetStatus{
+			Name:  name,
+			Error: clientv1alpha1.ErrInstanceNotFound.Error(),
+		})
+	}
+
+	message := "Schema Registry instance not found"
+	if len(missing) > 0 {
+		message += ": " + strings.Join(missing, ", ")
+	}
+	if len(failed) > 0 {
+		message += ", failed to delete subject from Schema Registry: " + strings.Join(failed, ", ")
+	}
+	schema.UpdateStatus(false, message)
+
+	if err := r.Status().Update(ctx, schema); err != nil {
+		logger.Error(err, "failed to update schema status")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: time.Minute}, nil
+}
+
+// UpdateReconciler updates the schema in each schema registry, independently of the others
 func (r *SchemaReconciler) UpdateReconciler(
 	ctx context.Context,
 	schema *clientv1alpha1.Schema,
-	schemaRegistry *clientv1alpha1.SchemaRegistry,
+	schemaRegistries []clientv1alpha1.SchemaRegistry,
+	missing []string,
 	logger logr.Logger,
 ) (ctrl.Result, error) {
 	logger.Info("Updating Schema: ", "Name", schema.Name, "Namespace", schema.Namespace)
-	srSchemaObject, err := schemaRegistry.DeploySchema(ctx, schema, logger)
-	if err != nil {
-		logger.Error(err, "failed to deploy schema to schema registry", "schema", schema)
+	if schema.Spec.DryRun {
+		return r.DryRunReconciler(ctx, schema, schemaRegistries, logger)
+	}
 
-		if errors.Is(err, clientv1alpha1.ErrIncompatibleSchema) || errors.Is(err, clientv1alpha1.ErrInvalidSchemaOrType) {
-			schema.Status.SchemaRegistryError = errors.Unwrap(err).Error()
+	schema.Status.Plan = nil
+	schema.Status.Targets = nil
+	failed := slices.Clone(missing)
+	recorded := false
+	for i := range schemaRegistries {
+		schemaRegistry := &schemaRegistries[i]
+		targetStatus := clientv1alpha1.SchemaTargetStatus{Name: schemaRegistry.Name}
+
+		// The purpose is to leave the schema registry untouched while its reconciliation is paused
+		if clientv1alpha1.IsPaused(schemaRegistry.ObjectMeta) {
+			logger.Info("schema registry reconciliation is paused", "SchemaRegistry", schemaRegistry.Name)
+			targetStatus.Error = clientv1alpha1.ErrInstancePaused.Error()
+			schema.Status.Targets = append(schema.Status.Targets, targetStatus)
+			failed = append(failed, schemaRegistry.Name)
+			continue
 		}
 
-		schema.UpdateStatus(false, "Failed to deploy schema to Schema Registry: "+schemaRegistry.Name)
-
-		if err = r.Status().Update(ctx, schema); err != nil {
-			logger.Error(err, "failed to update schema status")
-			return ctrl.Result{}, err
+		srSchemaObject, err := r.deploySchema(ctx, schema, schemaRegistry, logger)
+		if err != nil {
+			logger.Error(err, "failed to deploy schema to schema registry", "SchemaRegistry", schemaRegistry.Name)
+			if errors.Is(err, clientv1alpha1.ErrIncompatibleSchema) || errors.Is(err, clientv1alpha1.ErrInvalidSchemaOrType) {
+				schema.Status.SchemaRegistryError = errors.Unwrap(err).Error()
+			}
+
+			targetStatus.Error = err.Error()
+			schema.Status.Targets = append(schema.Status.Targets, targetStatus)
+			failed = append(failed, schemaRegistry.Name)
+			continue
 		}
 
-		return ctrl.Result{RequeueAfter: time.Minute}, err
+		targetStatus.Ready = true
+		targetStatus.LatestVersion = ptr.Deref(srSchemaObject.Version, 0)
+		targetStatus.SchemaID = ptr.Deref(srSchemaObject.Id, 0)
+		schema.Status.Targets = append(schema.Status.Targets, targetStatus)
+		schema.RecordRegistry(schemaRegistry.Name)
+
+		// The version, schema ID and history of the first instance the schema is deployed to are recorded
+		// in the status, so a failing first instance does not hide the deployments to the others
+		if !recorded {
+			if err = schema.RecordRegisteredSchema(schemaRegistry.Name, srSchemaObject, nil); err != nil {
+				logger.Error(err, "failed to record schema history")
+				return ctrl.Result{}, err
+			}
+			recorded = true
+		}
 	}
 
-	err = schemaRegistry.ChangeCompatibilityLevel(ctx, schema, logger)
+	untargetedFailed, err := r.deleteUntargeted(ctx, schema, schemaRegistries, logger)
 	if err != nil {
-		logger.Error(err, "failed to change compatibility level")
-		schema.UpdateStatus(false, "Failed to change compatibility level in Schema Registry: "+schemaRegistry.Name)
+		return ctrl.Result{}, err
+	}
+	failed = append(failed, untargetedFailed...)
+
+	for _, name := range missing {
+		schema.Status.Targets = append(schema.Status.Targets, clientv1alpha1.SchemaTargetStatus{
+			Name:  name,
+			Error: clientv1alpha1.ErrInstanceNotFound.Error(),
+		})
+	}
 
-		if err = r.Status().Update(ctx, schema); err != nil {
+	if len(failed) > 0 {
+		schema.UpdateStatus(false, "Failed to deploy schema to Schema Registry: "+strings.Join(failed, ", "))
+
+		if err := r.Status().Update(ctx, schema); err != nil {
 			logger.Error(err, "failed to update schema status")
 			return ctrl.Result{}, err
 		}
-		return ctrl.Result{RequeueAfter: time.Minute}, err
+
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
 	}
 
 	schema.UpdateStatus(true, SchemaDeployedSuccess)
-	schema.Status.LatestVersion = int(*srSchemaObject.Version)
+	if err := r.Status().Update(ctx, schema); err != nil {
+		logger.Error(err, "failed to update schema status")
+		return ctrl.Result{}, err
+	}
+
+	secondsTillNextReconcile := time.Duration(schema.Spec.SchemaRegistryConfig.SyncInterval) * time.Second
+	return ctrl.Result{RequeueAfter: secondsTillNextReconcile}, nil
+}
+
+// deleteUntargeted deletes the subject from the schema registry instances it is registered in, but which are no
+// longer targeted by the schema, and returns the names of the instances it could not be deleted from. The subject
+// is kept while schemas of other subjects in the instance reference it, unless the schema is force deleted.
+func (r *SchemaReconciler) deleteUntargeted(
+	ctx context.Context,
+	schema *clientv1alpha1.Schema,
+	targets []clientv1alpha1.SchemaRegistry,
+	logger logr.Logger,
+) ([]string, error) {
+	untargeted, gone, err := schema.UntargetedRegistries(ctx, r, targets)
+	if err != nil {
+		logger.Error(err, "failed to get untargeted schema registry instances")
+		return nil, err
+	}
+
+	// The subject is gone together with the instances which no longer exist
+	for _, name := range gone {
+		schema.ForgetRegistry(name)
+	}
+
+	var failed []string
+	for i := range untargeted {
+		schemaRegistry := &untargeted[i]
+		if err = r.deleteFromUntargeted(ctx, schema, schemaRegistry, logger); err != nil {
+			logger.Error(err, "failed to delete schema from untargeted schema registry", "SchemaRegistry", schemaRegistry.Name)
+			schema.Status.Targets = append(schema.Status.Targets, clientv1alpha1.SchemaTargetStatus{
+				Name:  schemaRegistry.Name,
+				Error: "Failed to delete subject from the no longer targeted instance: " + err.Error(),
+			})
+			failed = append(failed, schemaRegistry.Name)
+			continue
+		}
+
+		schema.ForgetRegistry(schemaRegistry.Name)
+	}
+
+	return failed, nil
+}
+
+// deleteFromUntargeted deletes the subject from a single schema registry instance which is no longer targeted
+func (r *SchemaReconciler) deleteFromUntargeted(
+	ctx context.Context,
+	schema *clientv1alpha1.Schema,
+	schemaRegistry *clientv1alpha1.SchemaRegistry,
+	logger logr.Logger,
+) error {
+	if clientv1alpha1.IsPaused(schemaRegistry.ObjectMeta) {
+		return fmt.Errorf("%w: %s", clientv1alpha1.ErrInstancePaused, schemaRegistry.Name)
+	}
+
+	if !schemaRegistry.Status.Ready {
+		return fmt.Errorf("schema registry %s is not ready", schemaRegistry.Name)
+	}
+
+	if !clientv1alpha1.IsForceDeleted(schema.ObjectMeta) {
+		referencing, err := schemaRegistry.FindReferencingSubjects(ctx, schema, logger)
+		if err != nil {
+			return err
+		}
+
+		if len(referencing) > 0 {
+			return fmt.Errorf("subject %s is referenced by: %s", schema.GetSubject(), strings.Join(referencing, ", "))
+		}
+	}
+
+	return schemaRegistry.DeleteSchema(ctx, schema, logger)
+}
+
+// deploySchema registers the schema, changes its compatibility level and enforces its retention policy
+// in a single schema registry, and returns the latest version
+func (r *SchemaReconciler) deploySchema(
+	ctx context.Context,
+	schema *clientv1alpha1.Schema,
+	schemaRegistry *clientv1alpha1.SchemaRegistry,
+	logger logr.Logger,
+) (*srclient.Schema, error) {
+	srSchemaObject, err := schemaRegistry.DeploySchema(ctx, schema, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = schemaRegistry.ChangeCompatibilityLevel(ctx, schema, logger); err != nil {
+		return nil, err
+	}
+
+	if _, err = schemaRegistry.EnforceRetention(ctx, schema, logger); err != nil {
+		return nil, fmt.Errorf("failed to enforce retention policy: %w", err)
+	}
+
+	return srSchemaObject, nil
+}
+
+// DryRunReconciler plans the changes to each schema registry and records them in the status without applying them
+func (r *SchemaReconciler) DryRunReconciler(
+	ctx context.Context,
+	schema *clientv1alpha1.Schema,
+	schemaRegistries []clientv1alpha1.SchemaRegistry,
+	logger logr.Logger,
+) (ctrl.Result, error) {
+	logger.Info("Planning Schema: ", "Name", schema.Name, "Namespace", schema.Namespace)
+
+	var plan []string
+	accepted := true
+	for i := range schemaRegistries {
+		schemaRegistry := &schemaRegistries[i]
+		targetPlan, targetAccepted, err := schemaRegistry.PlanSchema(ctx, schema, logger)
+		if err != nil {
+			logger.Error(err, "failed to plan schema")
+			schema.UpdateStatus(false, "Failed to plan schema in Schema Registry: "+schemaRegistry.Name)
+
+			if err = r.Status().Update(ctx, schema); err != nil {
+				logger.Error(err, "failed to update schema status")
+				return ctrl.Result{}, err
+			}
+
+			return ctrl.Result{RequeueAfter: time.Minute}, nil
+		}
+
+		for _, change := range targetPlan {
+			if len(schemaRegistries) > 1 {
+				change = schemaRegistry.Name + ": " + change
+			}
+			plan = append(plan, change)
+		}
+		accepted = accepted && targetAccepted
+	}
+
+	// The subject is also planned to be deleted from the instances which are no longer targeted
+	untargeted, _, err := schema.UntargetedRegistries(ctx, r, schemaRegistries)
+	if err != nil {
+		logger.Error(err, "failed to get untargeted schema registry instances")
+		return ctrl.Result{}, err
+	}
+	for i := range untargeted {
+		plan = append(plan, untargeted[i].Name+": would delete subject "+schema.GetSubject())
+	}
+
+	// Nothing is applied in dry run mode, so the targets of an earlier deployment are not reported as applied
+	schema.Status.Plan = plan
+	schema.Status.Targets = nil
+	if accepted {
+		schema.UpdateStatus(true, SchemaDryRunSuccess)
+	} else {
+		schema.UpdateStatus(false, SchemaDryRunRejected)
+	}
 
-	if err = r.Status().Update(ctx, schema); err != nil {
+	if err := r.Status().Update(ctx, schema); err != nil {
 		logger.Error(err, "failed to update schema status")
 		return ctrl.Result{}, err
 	}
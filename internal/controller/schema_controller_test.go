@@ -26,17 +26,21 @@ package controller
 
 import (
 	"context"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	clientv1alpha1 "github.com/steffen-karlsson/schema-registry-operator/api/v1alpha1"
 	k8s_manager "github.com/steffen-karlsson/schema-registry-operator/pkg/k8s"
+	"github.com/steffen-karlsson/schema-registry-operator/pkg/srclient"
 )
 
 var _ = Describe("Schema Controller", func() {
@@ -78,8 +82,9 @@ var _ = Describe("Schema Controller", func() {
 		It("should successfully reconcile the resource", func() {
 			By("Reconciling the created resource")
 			controllerReconciler := &SchemaReconciler{
-				Client: *k8s_manager.NewClient(k8sClient),
-				Scheme: k8sClient.Scheme(),
+				Client:   *k8s_manager.NewClient(k8sClient),
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(10),
 			}
 
 			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
@@ -91,3 +96,564 @@ var _ = Describe("Schema Controller", func() {
 		})
 	})
 })
+
+var _ = Describe("Schema targets", func() {
+	const (
+		resourceName = "test-targets"
+		subject      = "orders-value"
+		content      = `{"type":"record","name":"Order","fields":[{"name":"id","type":"string"}]}`
+	)
+
+	var (
+		ctx        context.Context
+		registries *fakeRegistries
+		primary    *clientv1alpha1.SchemaRegistry
+		secondary  *clientv1alpha1.SchemaRegistry
+	)
+
+	typeNamespacedName := types.NamespacedName{
+		Name:      resourceName,
+		Namespace: "default",
+	}
+
+	newSchema := func(targets ...string) *clientv1alpha1.Schema {
+		schema := &clientv1alpha1.Schema{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       resourceName,
+				Namespace:  "default",
+				Labels:     instanceLabels(primary.Name),
+				Finalizers: []string{SchemaFinalizer},
+			},
+			Spec: clientv1alpha1.SchemaSpec{
+				Subject:            "orders",
+				Target:             "VALUE",
+				Type:               "AVRO",
+				Content:            content,
+				CompatibilityLevel: "NONE",
+			},
+		}
+		if len(targets) > 0 {
+			schema.Spec.Targets = &clientv1alpha1.SchemaTargets{Names: targets}
+		}
+
+		return schema
+	}
+
+	reconcileSchema := func(c *k8s_manager.Client) (reconcile.Result, *clientv1alpha1.Schema) {
+		controllerReconciler := &SchemaReconciler{
+			Client:   *c,
+			Scheme:   c.Scheme(),
+			Recorder: record.NewFakeRecorder(10),
+		}
+
+		result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		schema := &clientv1alpha1.Schema{}
+		if err = c.Get(ctx, typeNamespacedName, schema); errors.IsNotFound(err) {
+			return result, nil
+		}
+		Expect(err).NotTo(HaveOccurred())
+
+		return result, schema
+	}
+
+	untarget := func(c *k8s_manager.Client) {
+		schema := &clientv1alpha1.Schema{}
+		Expect(c.Get(ctx, typeNamespacedName, schema)).To(Succeed())
+		schema.Spec.Targets = nil
+		Expect(c.Update(ctx, schema)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		registries = newFakeRegistries()
+		primary = newReadySchemaRegistry("sr-primary")
+		secondary = newReadySchemaRegistry("sr-secondary")
+	})
+
+	AfterEach(func() {
+		registries.Close()
+	})
+
+	It("should deploy the schema to every target and record the registries it is registered in", func() {
+		c := newFakeClient(primary, secondary, newSchema(secondary.Name))
+
+		_, schema := reconcileSchema(c)
+		Expect(schema.Status.Ready).To(BeTrue())
+		Expect(registries.registry(primary).activeVersions(subject)).To(Equal([]int32{1}))
+		Expect(registries.registry(secondary).activeVersions(subject)).To(Equal([]int32{1}))
+		Expect(schema.Status.Registries).To(Equal([]string{primary.Name, secondary.Name}))
+		Expect(schema.Status.Targets).To(HaveLen(2))
+	})
+
+	It("should record the version of the first instance the schema is deployed to", func() {
+		registries.registry(primary).credentials = "user:secret"
+		registries.registry(secondary).register("other-value", `"string"`)
+		c := newFakeClient(primary, secondary, newSchema(secondary.Name))
+
+		result, schema := reconcileSchema(c)
+		Expect(result.RequeueAfter).NotTo(BeZero())
+		Expect(schema.Status.Ready).To(BeFalse())
+		Expect(schema.Status.Message).To(ContainSubstring(primary.Name))
+
+		By("recording the version and schema ID of the secondary instance")
+		registered := registries.registry(secondary).version(subject, 1)
+		Expect(registered).NotTo(BeNil())
+		Expect(schema.Status.LatestVersion).To(Equal(1))
+		Expect(schema.Status.SchemaID).To(Equal(registered.ID))
+		Expect(schema.Status.History).To(HaveLen(1))
+		Expect(schema.Status.History[0].ID).To(Equal(registered.ID))
+		Expect(schema.Status.Registries).To(Equal([]string{secondary.Name}))
+	})
+
+	It("should delete the subject from instances which are no longer targeted", func() {
+		c := newFakeClient(primary, secondary, newSchema(secondary.Name))
+		reconcileSchema(c)
+
+		untarget(c)
+		_, schema := reconcileSchema(c)
+		Expect(schema.Status.Ready).To(BeTrue())
+		Expect(registries.registry(primary).activeVersions(subject)).To(Equal([]int32{1}))
+		Expect(registries.registry(secondary).activeVersions(subject)).To(BeEmpty())
+		Expect(schema.Status.Registries).To(Equal([]string{primary.Name}))
+	})
+
+	It("should keep the subject in an instance no longer targeted while it is referenced", func() {
+		c := newFakeClient(primary, secondary, newSchema(secondary.Name))
+		reconcileSchema(c)
+		registries.registry(secondary).register("invoice-value", `{"type":"record","name":"Invoice","fields":[]}`,
+			srclient.SchemaReference{Name: ptr.To("Order"), Subject: ptr.To(subject), Version: ptr.To(int32(1))})
+
+		untarget(c)
+		result, schema := reconcileSchema(c)
+		Expect(result.RequeueAfter).NotTo(BeZero())
+		Expect(schema.Status.Ready).To(BeFalse())
+		Expect(registries.registry(secondary).activeVersions(subject)).To(Equal([]int32{1}))
+		Expect(schema.Status.Registries).To(Equal([]string{primary.Name, secondary.Name}))
+		Expect(schema.Status.Targets).To(ContainElement(And(
+			HaveField("Name", secondary.Name),
+			HaveField("Error", ContainSubstring("invoice-value")),
+		)))
+	})
+
+	It("should forget instances which no longer exist", func() {
+		schema := newSchema()
+		schema.Status.Registries = []string{primary.Name, "sr-removed"}
+		c := newFakeClient(primary, schema)
+
+		_, schema = reconcileSchema(c)
+		Expect(schema.Status.Ready).To(BeTrue())
+		Expect(schema.Status.Registries).To(Equal([]string{primary.Name}))
+	})
+
+	It("should delete the subject from every instance it is registered in when the schema is deleted", func() {
+		registries.registry(primary).register(subject, content)
+		registries.registry(secondary).register(subject, content)
+		schema := newSchema()
+		schema.Status.Registries = []string{primary.Name, secondary.Name}
+		c := newFakeClient(primary, secondary, schema)
+		Expect(c.Delete(ctx, schema)).To(Succeed())
+
+		_, schema = reconcileSchema(c)
+		Expect(schema).To(BeNil())
+		Expect(registries.registry(primary).activeVersions(subject)).To(BeEmpty())
+		Expect(registries.registry(secondary).activeVersions(subject)).To(BeEmpty())
+	})
+
+	It("should delete the subject from every instance it is registered in when the last target is removed", func() {
+		schema := newSchema(secondary.Name)
+		schema.Labels = nil
+		c := newFakeClient(primary, secondary, schema)
+		_, schema = reconcileSchema(c)
+		Expect(schema.Status.Registries).To(Equal([]string{secondary.Name}))
+
+		untarget(c)
+		result, schema := reconcileSchema(c)
+		Expect(result.RequeueAfter).To(Equal(time.Minute))
+		Expect(schema.Status.Ready).To(BeFalse())
+		Expect(schema.Status.Message).To(Equal("Schema Registry instance not found"))
+		Expect(schema.Status.Targets).To(BeEmpty())
+		Expect(schema.Status.Registries).To(BeEmpty())
+		Expect(registries.registry(secondary).activeVersions(subject)).To(BeEmpty())
+	})
+
+	It("should delete the subject from the instances which still exist when the schema is deleted", func() {
+		registries.registry(primary).register(subject, content)
+		registries.registry(secondary).register(subject, content)
+		schema := newSchema(secondary.Name, "sr-removed")
+		schema.Status.Registries = []string{primary.Name, secondary.Name, "sr-removed"}
+		c := newFakeClient(primary, secondary, schema)
+		Expect(c.Delete(ctx, schema)).To(Succeed())
+
+		_, schema = reconcileSchema(c)
+		Expect(schema).To(BeNil())
+		Expect(registries.registry(primary).activeVersions(subject)).To(BeEmpty())
+		Expect(registries.registry(secondary).activeVersions(subject)).To(BeEmpty())
+	})
+
+	It("should delete the subject when every instance it targets no longer exists", func() {
+		schema := newSchema()
+		schema.Labels = instanceLabels("sr-removed")
+		schema.Status.Registries = []string{"sr-removed"}
+		c := newFakeClient(primary, schema)
+		Expect(c.Delete(ctx, schema)).To(Succeed())
+
+		_, schema = reconcileSchema(c)
+		Expect(schema).To(BeNil())
+	})
+})
+
+var _ = Describe("Schema retention", func() {
+	const (
+		resourceName = "test-retention"
+		subject      = "orders-value"
+	)
+
+	var (
+		ctx        context.Context
+		registries *fakeRegistries
+		primary    *clientv1alpha1.SchemaRegistry
+		secondary  *clientv1alpha1.SchemaRegistry
+	)
+
+	contents := []string{
+		`{"type":"record","name":"Order","fields":[{"name":"id","type":"string"}]}`,
+		`{"type":"record","name":"Order","fields":[{"name":"id","type":"string"},{"name":"a","type":"string","default":""}]}`,
+		`{"type":"record","name":"Order","fields":[{"name":"id","type":"string"},{"name":"b","type":"string","default":""}]}`,
+		`{"type":"record","name":"Order","fields":[{"name":"id","type":"string"},{"name":"c","type":"string","default":""}]}`,
+	}
+
+	typeNamespacedName := types.NamespacedName{
+		Name:      resourceName,
+		Namespace: "default",
+	}
+
+	newSchema := func(retention *clientv1alpha1.SchemaRetention) *clientv1alpha1.Schema {
+		return &clientv1alpha1.Schema{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       resourceName,
+				Namespace:  "default",
+				Labels:     instanceLabels(primary.Name),
+				Finalizers: []string{SchemaFinalizer},
+			},
+			Spec: clientv1alpha1.SchemaSpec{
+				Subject:            "orders",
+				Target:             "VALUE",
+				Type:               "AVRO",
+				Content:            contents[3],
+				CompatibilityLevel: "BACKWARD",
+				Retention:          retention,
+			},
+		}
+	}
+
+	reconcileSchema := func(c *k8s_manager.Client) *clientv1alpha1.Schema {
+		controllerReconciler := &SchemaReconciler{
+			Client:   *c,
+			Scheme:   c.Scheme(),
+			Recorder: record.NewFakeRecorder(10),
+		}
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		schema := &clientv1alpha1.Schema{}
+		Expect(c.Get(ctx, typeNamespacedName, schema)).To(Succeed())
+
+		return schema
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		registries = newFakeRegistries()
+		primary = newReadySchemaRegistry("sr-primary")
+		secondary = newReadySchemaRegistry("sr-secondary")
+
+		for _, registry := range []*clientv1alpha1.SchemaRegistry{primary, secondary} {
+			for _, content := range contents[:3] {
+				registries.registry(registry).register(subject, content)
+			}
+		}
+	})
+
+	AfterEach(func() {
+		registries.Close()
+	})
+
+	It("should permanently delete the expired versions which are not referenced", func() {
+		registries.registry(primary).register("invoice-value", `{"type":"record","name":"Invoice","fields":[]}`,
+			srclient.SchemaReference{Name: ptr.To("Order"), Subject: ptr.To(subject), Version: ptr.To(int32(1))})
+		c := newFakeClient(primary, newSchema(&clientv1alpha1.SchemaRetention{KeepLast: ptr.To(int32(1))}))
+
+		schema := reconcileSchema(c)
+		Expect(schema.Status.Ready).To(BeTrue())
+		Expect(schema.Status.LatestVersion).To(Equal(4))
+
+		By("keeping the referenced version 1 and the latest version")
+		Expect(registries.registry(primary).activeVersions(subject)).To(Equal([]int32{1, 4}))
+		Expect(registries.registry(primary).version(subject, 2)).To(BeNil())
+		Expect(registries.registry(primary).version(subject, 3)).To(BeNil())
+	})
+
+	It("should only expire versions by age in the instance the history is recorded for", func() {
+		schema := newSchema(&clientv1alpha1.SchemaRetention{MaxAge: &metav1.Duration{Duration: 24 * time.Hour}})
+		schema.Spec.Targets = &clientv1alpha1.SchemaTargets{Names: []string{secondary.Name}}
+		for _, version := range []int32{1, 2, 3} {
+			schema.Status.History = append(schema.Status.History, clientv1alpha1.SchemaHistoryEntry{
+				Version:      version,
+				Registry:     primary.Name,
+				RegisteredAt: metav1.NewTime(time.Now().Add(-72 * time.Hour)),
+			})
+		}
+		c := newFakeClient(primary, secondary, schema)
+
+		schema = reconcileSchema(c)
+		Expect(schema.Status.Ready).To(BeTrue())
+		Expect(registries.registry(primary).activeVersions(subject)).To(Equal([]int32{4}))
+		Expect(registries.registry(secondary).activeVersions(subject)).To(Equal([]int32{1, 2, 3, 4}))
+	})
+})
+
+var _ = Describe("Schema pause", func() {
+	const (
+		resourceName = "test-pause"
+		subject      = "orders-value"
+		content      = `{"type":"record","name":"Order","fields":[{"name":"id","type":"string"}]}`
+	)
+
+	var (
+		ctx        context.Context
+		registries *fakeRegistries
+		primary    *clientv1alpha1.SchemaRegistry
+	)
+
+	typeNamespacedName := types.NamespacedName{
+		Name:      resourceName,
+		Namespace: "default",
+	}
+
+	newSchema := func(annotations map[string]string) *clientv1alpha1.Schema {
+		return &clientv1alpha1.Schema{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        resourceName,
+				Namespace:   "default",
+				Labels:      instanceLabels(primary.Name),
+				Annotations: annotations,
+				Finalizers:  []string{SchemaFinalizer},
+			},
+			Spec: clientv1alpha1.SchemaSpec{
+				Subject:            "orders",
+				Target:             "VALUE",
+				Type:               "AVRO",
+				Content:            content,
+				CompatibilityLevel: "NONE",
+			},
+		}
+	}
+
+	reconcileSchema := func(c *k8s_manager.Client) (reconcile.Result, *clientv1alpha1.Schema) {
+		controllerReconciler := &SchemaReconciler{
+			Client:   *c,
+			Scheme:   c.Scheme(),
+			Recorder: record.NewFakeRecorder(10),
+		}
+
+		result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		schema := &clientv1alpha1.Schema{}
+		if err = c.Get(ctx, typeNamespacedName, schema); errors.IsNotFound(err) {
+			return result, nil
+		}
+		Expect(err).NotTo(HaveOccurred())
+
+		return result, schema
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		registries = newFakeRegistries()
+		primary = newReadySchemaRegistry("sr-primary")
+	})
+
+	AfterEach(func() {
+		registries.Close()
+	})
+
+	It("should leave the subject untouched while the schema is paused and deploy it once resumed", func() {
+		c := newFakeClient(primary, newSchema(map[string]string{clientv1alpha1.PausedAnnotation: "true"}))
+
+		result, schema := reconcileSchema(c)
+		Expect(result.RequeueAfter).To(BeZero())
+		Expect(schema.Status.Paused).To(BeTrue())
+		Expect(schema.Status.Message).To(Equal(SchemaPaused))
+		Expect(registries.registry(primary).activeVersions(subject)).To(BeEmpty())
+
+		By("resuming the schema")
+		delete(schema.Annotations, clientv1alpha1.PausedAnnotation)
+		Expect(c.Update(ctx, schema)).To(Succeed())
+
+		_, schema = reconcileSchema(c)
+		Expect(schema.Status.Paused).To(BeFalse())
+		Expect(schema.Status.Ready).To(BeTrue())
+		Expect(registries.registry(primary).activeVersions(subject)).To(Equal([]int32{1}))
+	})
+
+	It("should record a forced reconciliation", func() {
+		c := newFakeClient(primary, newSchema(map[string]string{clientv1alpha1.ForceReconcileAnnotation: "1700000000"}))
+
+		_, schema := reconcileSchema(c)
+		Expect(schema.Status.Ready).To(BeTrue())
+		Expect(schema.Status.LastForceReconcile).To(Equal("1700000000"))
+	})
+
+	It("should leave a paused Schema Registry untouched", func() {
+		primary.Annotations = map[string]string{clientv1alpha1.PausedAnnotation: "true"}
+		c := newFakeClient(primary, newSchema(nil))
+
+		result, schema := reconcileSchema(c)
+		Expect(result.RequeueAfter).NotTo(BeZero())
+		Expect(schema.Status.Ready).To(BeFalse())
+		Expect(schema.Status.Targets).To(ContainElement(And(
+			HaveField("Name", primary.Name),
+			HaveField("Error", clientv1alpha1.ErrInstancePaused.Error()),
+		)))
+		Expect(registries.registry(primary).activeVersions(subject)).To(BeEmpty())
+	})
+
+	It("should keep the subject and the finalizer while the Schema Registry is paused", func() {
+		registries.registry(primary).register(subject, content)
+		primary.Annotations = map[string]string{clientv1alpha1.PausedAnnotation: "true"}
+		schema := newSchema(nil)
+		c := newFakeClient(primary, schema)
+		Expect(c.Delete(ctx, schema)).To(Succeed())
+
+		result, schema := reconcileSchema(c)
+		Expect(result.RequeueAfter).NotTo(BeZero())
+		Expect(schema).NotTo(BeNil())
+		Expect(schema.Status.Message).To(Equal("Schema Registry sr-primary is paused"))
+		Expect(registries.registry(primary).activeVersions(subject)).To(Equal([]int32{1}))
+	})
+})
+
+var _ = Describe("Schema dry run", func() {
+	const (
+		resourceName = "test-dry-run"
+		subject      = "orders-value"
+		content      = `{"type":"record","name":"Order","fields":[{"name":"id","type":"string"}]}`
+	)
+
+	var (
+		ctx        context.Context
+		registries *fakeRegistries
+		primary    *clientv1alpha1.SchemaRegistry
+		secondary  *clientv1alpha1.SchemaRegistry
+	)
+
+	typeNamespacedName := types.NamespacedName{
+		Name:      resourceName,
+		Namespace: "default",
+	}
+
+	newSchema := func() *clientv1alpha1.Schema {
+		return &clientv1alpha1.Schema{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       resourceName,
+				Namespace:  "default",
+				Labels:     instanceLabels(primary.Name),
+				Finalizers: []string{SchemaFinalizer},
+			},
+			Spec: clientv1alpha1.SchemaSpec{
+				Subject:            "orders",
+				Target:             "VALUE",
+				Type:               "AVRO",
+				Content:            content,
+				CompatibilityLevel: "NONE",
+				DryRun:             true,
+			},
+		}
+	}
+
+	reconcileSchema := func(c *k8s_manager.Client) (reconcile.Result, *clientv1alpha1.Schema) {
+		controllerReconciler := &SchemaReconciler{
+			Client:   *c,
+			Scheme:   c.Scheme(),
+			Recorder: record.NewFakeRecorder(10),
+		}
+
+		result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		schema := &clientv1alpha1.Schema{}
+		if err = c.Get(ctx, typeNamespacedName, schema); errors.IsNotFound(err) {
+			return result, nil
+		}
+		Expect(err).NotTo(HaveOccurred())
+
+		return result, schema
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		registries = newFakeRegistries()
+		primary = newReadySchemaRegistry("sr-primary")
+		secondary = newReadySchemaRegistry("sr-secondary")
+	})
+
+	AfterEach(func() {
+		registries.Close()
+	})
+
+	It("should record the plan without registering the schema or reporting earlier targets as applied", func() {
+		registries.registry(primary).register(subject, `{"type":"record","name":"Order","fields":[]}`)
+		schema := newSchema()
+		schema.Status.Targets = []clientv1alpha1.SchemaTargetStatus{{Name: primary.Name, Ready: true, LatestVersion: 1}}
+		c := newFakeClient(primary, schema)
+
+		_, schema = reconcileSchema(c)
+		Expect(schema.Status.Ready).To(BeTrue())
+		Expect(schema.Status.Message).To(Equal(SchemaDryRunSuccess))
+		Expect(schema.Status.Plan).To(ContainElement("would register version 2"))
+		Expect(schema.Status.Targets).To(BeEmpty())
+		Expect(registries.registry(primary).activeVersions(subject)).To(Equal([]int32{1}))
+	})
+
+	It("should plan the deletion of the subject from instances which are no longer targeted", func() {
+		registries.registry(primary).register(subject, content)
+		registries.registry(secondary).register(subject, content)
+		schema := newSchema()
+		schema.Status.Registries = []string{primary.Name, secondary.Name}
+		c := newFakeClient(primary, secondary, schema)
+
+		_, schema = reconcileSchema(c)
+		Expect(schema.Status.Plan).To(ContainElement(secondary.Name + ": would delete subject " + subject))
+		Expect(schema.Status.Registries).To(Equal([]string{primary.Name, secondary.Name}))
+		Expect(registries.registry(secondary).activeVersions(subject)).To(Equal([]int32{1}))
+	})
+
+	It("should plan the deletion of the subject and keep the finalizer until dry run is disabled", func() {
+		registries.registry(primary).register(subject, content)
+		schema := newSchema()
+		schema.Status.Registries = []string{primary.Name}
+		c := newFakeClient(primary, schema)
+		Expect(c.Delete(ctx, schema)).To(Succeed())
+
+		result, schema := reconcileSchema(c)
+		Expect(result).To(Equal(reconcile.Result{}))
+		Expect(schema).NotTo(BeNil())
+		Expect(schema.Finalizers).To(ContainElement(SchemaFinalizer))
+		Expect(schema.Status.Ready).To(BeFalse())
+		Expect(schema.Status.Message).To(Equal(SchemaDryRunDeletion))
+		Expect(schema.Status.Plan).To(Equal([]string{"would delete subject " + subject}))
+		Expect(registries.registry(primary).activeVersions(subject)).To(Equal([]int32{1}))
+
+		By("deleting the subject once dry run is disabled")
+		schema.Spec.DryRun = false
+		Expect(c.Update(ctx, schema)).To(Succeed())
+
+		_, schema = reconcileSchema(c)
+		Expect(schema).To(BeNil())
+		Expect(registries.registry(primary).activeVersions(subject)).To(BeEmpty())
+	})
+})
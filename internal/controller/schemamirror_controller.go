@@ -0,0 +1,124 @@
+/*
+MIT License
+
+Copyright (c) 2025 Steffen Karlsson
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	clientv1alpha1 "github.com/steffen-karlsson/schema-registry-operator/api/v1alpha1"
+	k8s_manager "github.com/steffen-karlsson/schema-registry-operator/pkg/k8s"
+)
+
+// SchemaMirrorReconciler reconciles a SchemaMirror object
+type SchemaMirrorReconciler struct {
+	k8s_manager.Client
+	Scheme *runtime.Scheme
+	// APIReader reads the Secrets of the endpoints directly from the API server, so Secrets are not cached
+	APIReader client.Reader
+}
+
+// +kubebuilder:rbac:groups=client.sroperator.io,resources=schemamirrors,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=client.sroperator.io,resources=schemamirrors/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=client.sroperator.io,resources=schemamirrors/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+// the SchemaMirror object against the actual cluster state, and then
+// perform operations to make the cluster state reflect the state specified by
+// the user.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.19.0/pkg/reconcile
+func (r *SchemaMirrorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	logger.Info("Reconciling SchemaMirror: ", "Name", req.Name, "Namespace", req.Namespace)
+
+	mirror := &clientv1alpha1.SchemaMirror{}
+	err := r.Get(ctx, req.NamespacedName, mirror)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("schema mirror resource not found. Ignoring since object must be deleted")
+			return ctrl.Result{}, nil
+		}
+
+		logger.Error(err, "failed to get schema mirror")
+		return ctrl.Result{}, err
+	}
+
+	interval := mirror.Spec.Interval.Duration
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	source, destination, err := mirror.NewClients(ctx, r, r.APIReader)
+	if err != nil {
+		logger.Error(err, "failed to create schema registry clients")
+		mirror.UpdateStatus(false, "Failed to connect to schema registry: "+err.Error())
+
+		if err = r.Status().Update(ctx, mirror); err != nil {
+			logger.Error(err, "failed to update schema mirror status")
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+
+	if err = mirror.Sync(ctx, source, destination, logger); err != nil {
+		logger.Error(err, "failed to mirror subjects")
+		mirror.UpdateStatus(false, "Failed to mirror subjects: "+err.Error())
+
+		if err = r.Status().Update(ctx, mirror); err != nil {
+			logger.Error(err, "failed to update schema mirror status")
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+
+	if err = r.Status().Update(ctx, mirror); err != nil {
+		logger.Error(err, "failed to update schema mirror status")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: interval}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager. Status updates are ignored, as the sync time written
+// on every sync would otherwise requeue the mirror right away instead of after the interval.
+func (r *SchemaMirrorReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&clientv1alpha1.SchemaMirror{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
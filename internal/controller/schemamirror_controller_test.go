@@ -0,0 +1,296 @@
+/*
+MIT License
+
+Copyright (c) 2025 Steffen Karlsson
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	clientv1alpha1 "github.com/steffen-karlsson/schema-registry-operator/api/v1alpha1"
+	k8s_manager "github.com/steffen-karlsson/schema-registry-operator/pkg/k8s"
+	"github.com/steffen-karlsson/schema-registry-operator/pkg/srclient"
+)
+
+var _ = Describe("SchemaMirror Controller", func() {
+	const resourceName = "test-mirror"
+
+	var (
+		ctx         context.Context
+		registries  *fakeRegistries
+		source      *clientv1alpha1.SchemaRegistry
+		destination *clientv1alpha1.SchemaRegistry
+	)
+
+	typeNamespacedName := types.NamespacedName{
+		Name:      resourceName,
+		Namespace: "default",
+	}
+
+	reference := func(subject string, version int32) srclient.SchemaReference {
+		return srclient.SchemaReference{Name: ptr.To(subject), Subject: ptr.To(subject), Version: ptr.To(version)}
+	}
+
+	newMirror := func(pattern string) *clientv1alpha1.SchemaMirror {
+		return &clientv1alpha1.SchemaMirror{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      resourceName,
+				Namespace: "default",
+			},
+			Spec: clientv1alpha1.SchemaMirrorSpec{
+				Source:         clientv1alpha1.MirrorEndpoint{Instance: source.Name},
+				Destination:    clientv1alpha1.MirrorEndpoint{Instance: destination.Name},
+				SubjectPattern: pattern,
+				Interval:       metav1.Duration{Duration: 5 * time.Minute},
+			},
+		}
+	}
+
+	reconcileMirror := func(c *k8s_manager.Client) (reconcile.Result, *clientv1alpha1.SchemaMirror) {
+		controllerReconciler := &SchemaMirrorReconciler{
+			Client:    *c,
+			Scheme:    c.Scheme(),
+			APIReader: c,
+		}
+
+		result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		mirror := &clientv1alpha1.SchemaMirror{}
+		Expect(c.Get(ctx, typeNamespacedName, mirror)).To(Succeed())
+		return result, mirror
+	}
+
+	subjectStatus := func(mirror *clientv1alpha1.SchemaMirror, name string) clientv1alpha1.SubjectMirrorStatus {
+		for _, subject := range mirror.Status.Subjects {
+			if subject.Name == name {
+				return subject
+			}
+		}
+
+		Fail("subject " + name + " is not in the mirror status")
+		return clientv1alpha1.SubjectMirrorStatus{}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		registries = newFakeRegistries()
+		source = newReadySchemaRegistry("mirror-source")
+		destination = newReadySchemaRegistry("mirror-destination")
+	})
+
+	AfterEach(func() {
+		registries.Close()
+	})
+
+	Context("When mirroring between Schema Registry instances", func() {
+		It("should copy the subjects matching the pattern with their IDs and versions", func() {
+			sourceRegistry := registries.registry(source)
+			sourceRegistry.register("orders-value", `{"type":"string"}`)
+			sourceRegistry.register("orders-value", `{"type":"int"}`)
+			sourceRegistry.register("orders-key", `{"type":"long"}`)
+			sourceRegistry.register("customers-value", `{"type":"bytes"}`)
+			sourceRegistry.softDelete("orders-value", 1)
+
+			c := newFakeClient(source, destination, newMirror("orders-.*"))
+
+			result, mirror := reconcileMirror(c)
+			Expect(result.RequeueAfter).To(Equal(5 * time.Minute))
+			Expect(mirror.Status.Ready).To(BeTrue())
+			Expect(mirror.Status.Message).To(Equal("Mirrored 2 subjects"))
+			Expect(mirror.Status.Lag).To(BeZero())
+			Expect(meta.IsStatusConditionFalse(mirror.Status.Conditions, clientv1alpha1.SchemaMirrorConditionPaused)).To(BeTrue())
+
+			Expect(mirror.Status.Subjects).To(HaveLen(2))
+			orders := subjectStatus(mirror, "orders-value")
+			Expect(orders.LatestVersion).To(Equal(int32(2)))
+			Expect(orders.LastSyncedVersion).To(Equal(int32(2)))
+			Expect(orders.LastSyncTime).NotTo(BeNil())
+			Expect(orders.Message).To(BeEmpty())
+
+			destinationRegistry := registries.registry(destination)
+			Expect(destinationRegistry.activeVersions("orders-value")).To(Equal([]int32{2}))
+			Expect(destinationRegistry.version("orders-value", 1).Deleted).To(BeTrue())
+			Expect(destinationRegistry.version("orders-value", 2).ID).To(Equal(sourceRegistry.version("orders-value", 2).ID))
+			Expect(destinationRegistry.version("orders-key", 1).ID).To(Equal(sourceRegistry.version("orders-key", 1).ID))
+			Expect(destinationRegistry.activeVersions("customers-value")).To(BeEmpty())
+			Expect(destinationRegistry.subjectMode("orders-value")).To(Equal(string(srclient.ModeUpdateRequestModeIMPORT)))
+
+			By("copying new versions on the next synchronization")
+			sourceRegistry.register("orders-value", `{"type":"double"}`)
+
+			_, mirror = reconcileMirror(c)
+			Expect(mirror.Status.Ready).To(BeTrue())
+			Expect(subjectStatus(mirror, "orders-value").LastSyncedVersion).To(Equal(int32(3)))
+			Expect(destinationRegistry.activeVersions("orders-value")).To(Equal([]int32{2, 3}))
+		})
+
+		It("should copy referenced subjects before the subjects referencing them", func() {
+			sourceRegistry := registries.registry(source)
+			sourceRegistry.register("z-customer", `{"type":"record","name":"Customer"}`)
+			sourceRegistry.register("a-order", `{"type":"record","name":"Order"}`, reference("z-customer", 1))
+
+			c := newFakeClient(source, destination, newMirror(".*"))
+
+			_, mirror := reconcileMirror(c)
+			Expect(mirror.Status.Ready).To(BeTrue())
+
+			destinationRegistry := registries.registry(destination)
+			Expect(destinationRegistry.activeVersions("z-customer")).To(Equal([]int32{1}))
+			Expect(destinationRegistry.activeVersions("a-order")).To(Equal([]int32{1}))
+			Expect(destinationRegistry.version("a-order", 1).References).To(ConsistOf(reference("z-customer", 1)))
+		})
+
+		It("should keep mirroring other subjects when a subject fails", func() {
+			sourceRegistry := registries.registry(source)
+			sourceRegistry.register("z-customer", `{"type":"record","name":"Customer"}`)
+			sourceRegistry.register("a-order", `{"type":"record","name":"Order"}`, reference("z-customer", 1))
+			sourceRegistry.register("a-payment", `{"type":"record","name":"Payment"}`)
+
+			c := newFakeClient(source, destination, newMirror("a-.*"))
+
+			result, mirror := reconcileMirror(c)
+			Expect(result.RequeueAfter).To(Equal(5 * time.Minute))
+			Expect(mirror.Status.Ready).To(BeFalse())
+			Expect(mirror.Status.Message).To(Equal("Failed to mirror 1 of 2 subjects: a-order"))
+			Expect(subjectStatus(mirror, "a-order").Message).NotTo(BeEmpty())
+			Expect(subjectStatus(mirror, "a-order").Lag).To(Equal(int32(1)))
+			Expect(subjectStatus(mirror, "a-payment").Message).To(BeEmpty())
+			Expect(mirror.Status.Lag).To(Equal(int32(1)))
+
+			destinationRegistry := registries.registry(destination)
+			Expect(destinationRegistry.activeVersions("a-payment")).To(Equal([]int32{1}))
+			Expect(destinationRegistry.activeVersions("a-order")).To(BeEmpty())
+		})
+
+		It("should pause when the destination has diverged", func() {
+			registries.registry(source).register("orders-value", `{"type":"string"}`)
+			registries.registry(destination).register("orders-value", `{"type":"string"}`)
+			registries.registry(destination).register("orders-value", `{"type":"int"}`)
+			registries.registry(source).register("orders-key", `{"type":"long"}`)
+
+			c := newFakeClient(source, destination, newMirror(".*"))
+
+			_, mirror := reconcileMirror(c)
+			Expect(mirror.Status.Ready).To(BeFalse())
+			Expect(mirror.Status.Message).To(Equal("Mirroring paused, destination has diverged from source"))
+			Expect(meta.IsStatusConditionTrue(mirror.Status.Conditions, clientv1alpha1.SchemaMirrorConditionPaused)).To(BeTrue())
+			Expect(subjectStatus(mirror, "orders-value").Divergence).To(Equal("version 2 only exists in the destination"))
+			Expect(registries.registry(destination).activeVersions("orders-key")).To(BeEmpty())
+		})
+
+		It("should wait for the Schema Registry instances to be ready", func() {
+			destination.Status.Ready = false
+			c := newFakeClient(source, destination, newMirror(".*"))
+
+			result, mirror := reconcileMirror(c)
+			Expect(result.RequeueAfter).To(Equal(time.Minute))
+			Expect(mirror.Status.Ready).To(BeFalse())
+			Expect(mirror.Status.Message).To(ContainSubstring("destination: " + clientv1alpha1.ErrInstanceNotReady.Error()))
+		})
+
+		It("should leave a paused destination Schema Registry untouched", func() {
+			destination.Annotations = map[string]string{clientv1alpha1.PausedAnnotation: "true"}
+			c := newFakeClient(source, destination, newMirror(".*"))
+
+			result, mirror := reconcileMirror(c)
+			Expect(result.RequeueAfter).To(Equal(time.Minute))
+			Expect(mirror.Status.Message).To(ContainSubstring("destination: " + clientv1alpha1.ErrInstancePaused.Error()))
+			Expect(registries.registry(destination).activeVersions("orders-value")).To(BeEmpty())
+		})
+	})
+
+	Context("When mirroring from an external schema registry", func() {
+		newExternalMirror := func() *clientv1alpha1.SchemaMirror {
+			mirror := newMirror(".*")
+			mirror.Spec.Source = clientv1alpha1.MirrorEndpoint{
+				URL: "http://external:8081",
+				BasicAuth: &clientv1alpha1.MirrorBasicAuth{
+					Username: corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "external-credentials"},
+						Key:                  "username",
+					},
+					Password: corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "external-credentials"},
+						Key:                  "password",
+					},
+				},
+			}
+			return mirror
+		}
+
+		credentials := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "external-credentials", Namespace: "default"},
+			Data: map[string][]byte{
+				"username": []byte("mirror"),
+				"password": []byte("secret"),
+			},
+		}
+
+		It("should authenticate with the credentials in the Secret", func() {
+			external := registries.host("external:8081")
+			external.credentials = "mirror:secret"
+			external.register("orders-value", `{"type":"string"}`)
+
+			c := newFakeClient(destination, newExternalMirror(), credentials.DeepCopy())
+
+			_, mirror := reconcileMirror(c)
+			Expect(mirror.Status.Ready).To(BeTrue())
+			Expect(registries.registry(destination).activeVersions("orders-value")).To(Equal([]int32{1}))
+		})
+
+		It("should report a missing Secret", func() {
+			c := newFakeClient(destination, newExternalMirror())
+
+			result, mirror := reconcileMirror(c)
+			Expect(result.RequeueAfter).To(Equal(time.Minute))
+			Expect(mirror.Status.Ready).To(BeFalse())
+			Expect(mirror.Status.Message).To(ContainSubstring("source: " + clientv1alpha1.ErrSecretKeyNotFound.Error()))
+		})
+
+		It("should report an invalid CA certificate", func() {
+			mirror := newExternalMirror()
+			mirror.Spec.Source.TLS = &clientv1alpha1.MirrorTLS{
+				CA: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "external-credentials"},
+					Key:                  "username",
+				},
+			}
+			c := newFakeClient(destination, mirror, credentials.DeepCopy())
+
+			_, mirror = reconcileMirror(c)
+			Expect(mirror.Status.Ready).To(BeFalse())
+			Expect(mirror.Status.Message).To(ContainSubstring(clientv1alpha1.ErrInvalidCertificate.Error()))
+		})
+	})
+})
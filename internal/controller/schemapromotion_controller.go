@@ -0,0 +1,314 @@
+/*
+MIT License
+
+Copyright (c) 2025 Steffen Karlsson
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	clientv1alpha1 "github.com/steffen-karlsson/schema-registry-operator/api/v1alpha1"
+	k8s_manager "github.com/steffen-karlsson/schema-registry-operator/pkg/k8s"
+)
+
+const (
+	PromotionCompletedSuccess = "Promotion completed successfully"
+)
+
+// SchemaPromotionReconciler reconciles a SchemaPromotion object
+type SchemaPromotionReconciler struct {
+	k8s_manager.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=client.sroperator.io,resources=schemapromotions,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=client.sroperator.io,resources=schemapromotions/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=client.sroperator.io,resources=schemapromotions/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+// the SchemaPromotion object against the actual cluster state, and then
+// perform operations to make the cluster state reflect the state specified by
+// the user.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.19.0/pkg/reconcile
+func (r *SchemaPromotionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	logger.Info("Reconciling SchemaPromotion: ", "Name", req.Name, "Namespace", req.Namespace)
+
+	promotion := &clientv1alpha1.SchemaPromotion{}
+	err := r.Get(ctx, req.NamespacedName, promotion)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("schema promotion resource not found. Ignoring since object must be deleted")
+			return ctrl.Result{}, nil
+		}
+
+		logger.Error(err, "failed to get schema promotion")
+		return ctrl.Result{}, err
+	}
+
+	// A promotion is only run once, so there is nothing left to do when it has completed
+	if promotion.Status.CompletionTime != nil {
+		return ctrl.Result{}, nil
+	}
+
+	if promotion.Status.Phase == "" {
+		promotion.Status.Phase = clientv1alpha1.PromotionPhasePending
+	}
+
+	schema := &clientv1alpha1.Schema{}
+	err = r.Get(ctx, types.NamespacedName{Name: promotion.Spec.Schema, Namespace: promotion.Namespace}, schema)
+	switch {
+	case apierrors.IsNotFound(err):
+		return r.requeueWithMessage(ctx, promotion, "Schema "+promotion.Spec.Schema+" not found", logger)
+	case err != nil:
+		logger.Error(err, "failed to get schema")
+		return ctrl.Result{}, err
+	}
+
+	source, err := r.sourceRegistry(ctx, promotion, schema)
+	if err != nil {
+		logger.Error(err, "failed to get source schema registry instance")
+		return ctrl.Result{}, err
+	}
+
+	target, err := r.getSchemaRegistry(ctx, promotion.Namespace, promotion.Spec.Target)
+	if err != nil {
+		logger.Error(err, "failed to get target schema registry instance")
+		return ctrl.Result{}, err
+	}
+
+	switch {
+	case source == nil:
+		return r.requeueWithMessage(ctx, promotion, "Source Schema Registry instance not found", logger)
+	case target == nil:
+		return r.requeueWithMessage(ctx, promotion, "Target Schema Registry instance not found", logger)
+	case !source.Status.Ready:
+		return r.requeueWithMessage(ctx, promotion, "Schema Registry "+source.Name+" is not ready", logger)
+	case !target.Status.Ready:
+		return r.requeueWithMessage(ctx, promotion, "Schema Registry "+target.Name+" is not ready", logger)
+	case clientv1alpha1.IsPaused(target.ObjectMeta):
+		return r.requeueWithMessage(ctx, promotion, "Schema Registry "+target.Name+" is paused", logger)
+	}
+
+	return r.PromotionReconciler(ctx, promotion, schema, source, target, logger)
+}
+
+// PromotionReconciler promotes the version from the source to the target, once it is compatible with the target
+// or approved, and records each step in the audit trail
+func (r *SchemaPromotionReconciler) PromotionReconciler(
+	ctx context.Context,
+	promotion *clientv1alpha1.SchemaPromotion,
+	schema *clientv1alpha1.Schema,
+	source *clientv1alpha1.SchemaRegistry,
+	target *clientv1alpha1.SchemaRegistry,
+	logger logr.Logger,
+) (ctrl.Result, error) {
+	logger.Info("Promoting Schema: ", "Name", schema.Name, "Source", source.Name, "Target", target.Name)
+
+	// The purpose is to pin the version when the latest version is promoted,
+	// so newer versions registered in the source while awaiting approval are not promoted
+	version := promotion.Status.SourceVersion
+	if version == 0 {
+		version = promotion.Spec.Version
+	}
+
+	registered, err := source.GetSchemaVersion(ctx, schema.GetSubject(), version, logger)
+	if err != nil {
+		logger.Error(err, "failed to get schema version from source", "SchemaRegistry", source.Name)
+		promotion.Audit(clientv1alpha1.PromotionEventFailed, err.Error())
+		return r.requeueWithMessage(ctx, promotion, "Failed to get schema version from Schema Registry: "+source.Name, logger)
+	}
+
+	if promotion.Status.SourceVersion == 0 {
+		promotion.Status.Subject = schema.GetSubject()
+		promotion.Status.SourceRegistry = source.Name
+		promotion.Status.SourceVersion = ptr.Deref(registered.Version, 0)
+		promotion.Audit(clientv1alpha1.PromotionEventSourceResolved, fmt.Sprintf("Version %d of subject %s in %s",
+			promotion.Status.SourceVersion, promotion.Status.Subject, source.Name))
+	}
+
+	promoted := schema.DeepCopy()
+	promoted.SetRegisteredSchema(registered)
+	if schemaType := ptr.Deref(registered.SchemaType, ""); schemaType != "" {
+		promoted.Spec.Type = schemaType
+	}
+
+	compatible, messages, err := target.TestCompatibility(ctx, promoted, logger)
+	if err != nil {
+		logger.Error(err, "failed to test compatibility against target", "SchemaRegistry", target.Name)
+		promotion.Audit(clientv1alpha1.PromotionEventFailed, err.Error())
+		return r.requeueWithMessage(ctx, promotion, "Failed to test compatibility in Schema Registry: "+target.Name, logger)
+	}
+
+	// The purpose is to keep the audit trail from growing on every requeue while the same breaking change
+	// is awaiting approval, as the compatibility check and the approval request would otherwise alternate
+	unchanged := promotion.Status.Phase == clientv1alpha1.PromotionPhaseAwaitingApproval && !compatible &&
+		slices.Equal(promotion.Status.CompatibilityMessages, messages)
+
+	promotion.Status.Compatible = compatible
+	promotion.Status.CompatibilityMessages = messages
+	if compatible {
+		promotion.Audit(clientv1alpha1.PromotionEventCompatibilityChecked, "Compatible with "+target.Name)
+	} else {
+		if !unchanged {
+			promotion.Audit(clientv1alpha1.PromotionEventCompatibilityChecked,
+				"Incompatible with "+target.Name+": "+strings.Join(messages, "; "))
+		}
+
+		approvedBy := promotion.ApprovedBy()
+		if approvedBy == "" {
+			if !unchanged {
+				promotion.Status.Phase = clientv1alpha1.PromotionPhaseAwaitingApproval
+				promotion.Audit(clientv1alpha1.PromotionEventApprovalRequired, "Annotate with "+
+					clientv1alpha1.ApprovedByAnnotation+" to promote the breaking change")
+			}
+			return r.requeueWithMessage(ctx, promotion, "Awaiting approval, the promotion would break compatibility", logger)
+		}
+		promotion.Audit(clientv1alpha1.PromotionEventApproved, "Approved by "+approvedBy)
+
+		// The purpose is to let the target accept the breaking change, the compatibility level of the
+		// Schema is restored once the version is registered
+		unchecked := promoted.DeepCopy()
+		unchecked.Spec.CompatibilityLevel = "NONE"
+		if err = target.ChangeCompatibilityLevel(ctx, unchecked, logger); err != nil {
+			logger.Error(err, "failed to change compatibility level", "SchemaRegistry", target.Name)
+			promotion.Audit(clientv1alpha1.PromotionEventFailed, err.Error())
+			return r.requeueWithMessage(ctx, promotion, "Failed to change compatibility level in Schema Registry: "+target.Name, logger)
+		}
+	}
+
+	deployed, err := target.DeploySchema(ctx, promoted, logger)
+	if err != nil {
+		logger.Error(err, "failed to deploy schema to target", "SchemaRegistry", target.Name)
+		promotion.Audit(clientv1alpha1.PromotionEventFailed, err.Error())
+
+		// The purpose is to not leave the subject of the target without compatibility checks,
+		// when the breaking change could not be registered
+		if !compatible {
+			if err = target.ChangeCompatibilityLevel(ctx, promoted, logger); err != nil {
+				logger.Error(err, "failed to restore compatibility level", "SchemaRegistry", target.Name)
+			}
+		}
+		return r.requeueWithMessage(ctx, promotion, "Failed to deploy schema to Schema Registry: "+target.Name, logger)
+	}
+
+	if err = target.ChangeCompatibilityLevel(ctx, promoted, logger); err != nil {
+		logger.Error(err, "failed to change compatibility level", "SchemaRegistry", target.Name)
+		promotion.Audit(clientv1alpha1.PromotionEventFailed, err.Error())
+		return r.requeueWithMessage(ctx, promotion, "Failed to change compatibility level in Schema Registry: "+target.Name, logger)
+	}
+
+	promotion.Status.Phase = clientv1alpha1.PromotionPhasePromoted
+	promotion.Status.TargetVersion = ptr.Deref(deployed.Version, 0)
+	promotion.Status.TargetSchemaID = ptr.Deref(deployed.Id, 0)
+	promotion.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+	promotion.Audit(clientv1alpha1.PromotionEventPromoted, fmt.Sprintf("Registered as version %d with schema ID %d in %s",
+		promotion.Status.TargetVersion, promotion.Status.TargetSchemaID, target.Name))
+	promotion.UpdateStatus(true, PromotionCompletedSuccess)
+
+	if err = r.Status().Update(ctx, promotion); err != nil {
+		logger.Error(err, "failed to update schema promotion status")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// sourceRegistry returns the SchemaRegistry the version is promoted from, which is nil when it is not found
+func (r *SchemaPromotionReconciler) sourceRegistry(
+	ctx context.Context,
+	promotion *clientv1alpha1.SchemaPromotion,
+	schema *clientv1alpha1.Schema,
+) (*clientv1alpha1.SchemaRegistry, error) {
+	if promotion.Spec.Source == "" {
+		targets, _, err := schema.ResolveTargets(ctx, r)
+		if err != nil || len(targets) == 0 {
+			return nil, err
+		}
+
+		return &targets[0], nil
+	}
+
+	return r.getSchemaRegistry(ctx, promotion.Namespace, promotion.Spec.Source)
+}
+
+// getSchemaRegistry returns the SchemaRegistry with the given name, which is nil when it is not found
+func (r *SchemaPromotionReconciler) getSchemaRegistry(
+	ctx context.Context,
+	namespace string,
+	name string,
+) (*clientv1alpha1.SchemaRegistry, error) {
+	schemaRegistry := &clientv1alpha1.SchemaRegistry{}
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, schemaRegistry)
+	switch {
+	case apierrors.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	return schemaRegistry, nil
+}
+
+// requeueWithMessage records the message in the status and requeues the promotion
+func (r *SchemaPromotionReconciler) requeueWithMessage(
+	ctx context.Context,
+	promotion *clientv1alpha1.SchemaPromotion,
+	message string,
+	logger logr.Logger,
+) (ctrl.Result, error) {
+	promotion.UpdateStatus(false, message)
+	if err := r.Status().Update(ctx, promotion); err != nil {
+		logger.Error(err, "failed to update schema promotion status")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: time.Minute}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager. Status updates are ignored, as they would otherwise
+// requeue the promotion right away, while the approval is an annotation and hence must trigger a reconciliation.
+func (r *SchemaPromotionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&clientv1alpha1.SchemaPromotion{}, builder.WithPredicates(
+			predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}))).
+		Complete(r)
+}
@@ -0,0 +1,261 @@
+/*
+MIT License
+
+Copyright (c) 2025 Steffen Karlsson
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	clientv1alpha1 "github.com/steffen-karlsson/schema-registry-operator/api/v1alpha1"
+	k8s_manager "github.com/steffen-karlsson/schema-registry-operator/pkg/k8s"
+)
+
+var _ = Describe("SchemaPromotion Controller", func() {
+	const (
+		resourceName = "test-promotion"
+		schemaName   = "test-schema"
+		subject      = "orders-value"
+	)
+
+	var (
+		ctx        context.Context
+		registries *fakeRegistries
+		source     *clientv1alpha1.SchemaRegistry
+		target     *clientv1alpha1.SchemaRegistry
+	)
+
+	contents := []string{
+		`{"type":"record","name":"Order","fields":[{"name":"id","type":"string"}]}`,
+		`{"type":"record","name":"Order","fields":[{"name":"id","type":"string"},{"name":"a","type":"string","default":""}]}`,
+		`{"type":"record","name":"Order","fields":[{"name":"id","type":"string"},{"name":"b","type":"string","default":""}]}`,
+	}
+
+	typeNamespacedName := types.NamespacedName{
+		Name:      resourceName,
+		Namespace: "default",
+	}
+
+	newSchema := func() *clientv1alpha1.Schema {
+		return &clientv1alpha1.Schema{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      schemaName,
+				Namespace: "default",
+				Labels:    instanceLabels(source.Name),
+			},
+			Spec: clientv1alpha1.SchemaSpec{
+				Subject:            "orders",
+				Target:             "VALUE",
+				Type:               "AVRO",
+				Content:            contents[1],
+				CompatibilityLevel: "BACKWARD",
+			},
+		}
+	}
+
+	newPromotion := func(version int32) *clientv1alpha1.SchemaPromotion {
+		return &clientv1alpha1.SchemaPromotion{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      resourceName,
+				Namespace: "default",
+			},
+			Spec: clientv1alpha1.SchemaPromotionSpec{
+				Schema:  schemaName,
+				Version: version,
+				Target:  target.Name,
+			},
+		}
+	}
+
+	reconcilePromotion := func(c *k8s_manager.Client) (reconcile.Result, *clientv1alpha1.SchemaPromotion) {
+		controllerReconciler := &SchemaPromotionReconciler{
+			Client: *c,
+			Scheme: c.Scheme(),
+		}
+
+		result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		promotion := &clientv1alpha1.SchemaPromotion{}
+		Expect(c.Get(ctx, typeNamespacedName, promotion)).To(Succeed())
+
+		return result, promotion
+	}
+
+	events := func(promotion *clientv1alpha1.SchemaPromotion) []string {
+		var events []string
+		for _, entry := range promotion.Status.AuditTrail {
+			events = append(events, entry.Event)
+		}
+
+		return events
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		registries = newFakeRegistries()
+		source = newReadySchemaRegistry("sr-staging")
+		target = newReadySchemaRegistry("sr-production")
+
+		for _, content := range contents[:2] {
+			registries.registry(source).register(subject, content)
+		}
+	})
+
+	AfterEach(func() {
+		registries.Close()
+	})
+
+	It("should register the requested version of the source in the target", func() {
+		c := newFakeClient(source, target, newSchema(), newPromotion(1))
+
+		result, promotion := reconcilePromotion(c)
+		Expect(result.RequeueAfter).To(BeZero())
+
+		By("registering the content of version 1 in the target")
+		Expect(registries.registry(target).activeVersions(subject)).To(Equal([]int32{1}))
+		registered := registries.registry(target).version(subject, 1)
+		Expect(registered.Schema).To(Equal(contents[0]))
+		Expect(registries.registry(target).subjectCompatibility(subject)).To(Equal("BACKWARD"))
+
+		By("completing the promotion")
+		Expect(promotion.Status.Ready).To(BeTrue())
+		Expect(promotion.Status.Message).To(Equal(PromotionCompletedSuccess))
+		Expect(promotion.Status.Phase).To(Equal(clientv1alpha1.PromotionPhasePromoted))
+		Expect(promotion.Status.Subject).To(Equal(subject))
+		Expect(promotion.Status.SourceRegistry).To(Equal(source.Name))
+		Expect(promotion.Status.SourceVersion).To(Equal(int32(1)))
+		Expect(promotion.Status.Compatible).To(BeTrue())
+		Expect(promotion.Status.TargetVersion).To(Equal(int32(1)))
+		Expect(promotion.Status.TargetSchemaID).To(Equal(registered.ID))
+		Expect(promotion.Status.CompletionTime).NotTo(BeNil())
+		Expect(events(promotion)).To(Equal([]string{
+			clientv1alpha1.PromotionEventSourceResolved,
+			clientv1alpha1.PromotionEventCompatibilityChecked,
+			clientv1alpha1.PromotionEventPromoted,
+		}))
+	})
+
+	It("should promote the latest version of the source when no version is requested", func() {
+		c := newFakeClient(source, target, newSchema(), newPromotion(0))
+
+		_, promotion := reconcilePromotion(c)
+		Expect(promotion.Status.SourceVersion).To(Equal(int32(2)))
+		Expect(registries.registry(target).version(subject, 1).Schema).To(Equal(contents[1]))
+	})
+
+	It("should await approval of a breaking change and promote the pinned version once approved", func() {
+		registries.registry(target).register(subject, contents[0])
+		registries.registry(target).incompatible[subject] = []string{"field b was removed"}
+		c := newFakeClient(source, target, newSchema(), newPromotion(0))
+
+		result, promotion := reconcilePromotion(c)
+		Expect(result.RequeueAfter).NotTo(BeZero())
+		Expect(promotion.Status.Phase).To(Equal(clientv1alpha1.PromotionPhaseAwaitingApproval))
+		Expect(promotion.Status.Compatible).To(BeFalse())
+		Expect(promotion.Status.CompatibilityMessages).To(Equal([]string{"field b was removed"}))
+		Expect(promotion.Status.SourceVersion).To(Equal(int32(2)))
+		Expect(registries.registry(target).activeVersions(subject)).To(Equal([]int32{1}))
+
+		By("not growing the audit trail while awaiting approval")
+		trail := events(promotion)
+		_, promotion = reconcilePromotion(c)
+		_, promotion = reconcilePromotion(c)
+		Expect(events(promotion)).To(Equal(trail))
+		Expect(promotion.Status.Phase).To(Equal(clientv1alpha1.PromotionPhaseAwaitingApproval))
+
+		By("registering a newer version in the source while awaiting approval")
+		registries.registry(source).register(subject, contents[2])
+
+		By("approving the promotion")
+		promotion.Annotations = map[string]string{clientv1alpha1.ApprovedByAnnotation: "jane"}
+		Expect(c.Update(ctx, promotion)).To(Succeed())
+
+		result, promotion = reconcilePromotion(c)
+		Expect(result.RequeueAfter).To(BeZero())
+		Expect(promotion.Status.Phase).To(Equal(clientv1alpha1.PromotionPhasePromoted))
+		Expect(promotion.Status.SourceVersion).To(Equal(int32(2)))
+		Expect(promotion.Status.TargetVersion).To(Equal(int32(2)))
+		Expect(registries.registry(target).version(subject, 2).Schema).To(Equal(contents[1]))
+		Expect(registries.registry(target).subjectCompatibility(subject)).To(Equal("BACKWARD"))
+		Expect(events(promotion)).To(ContainElements(
+			clientv1alpha1.PromotionEventApprovalRequired,
+			clientv1alpha1.PromotionEventApproved,
+			clientv1alpha1.PromotionEventPromoted,
+		))
+	})
+
+	It("should restore the compatibility level of the target when an approved breaking change fails", func() {
+		registries.registry(target).register(subject, contents[0])
+		registries.registry(target).incompatible[subject] = []string{"field b was removed"}
+		registries.registry(target).subjectLocked(subject).mode = "READONLY"
+		promotion := newPromotion(0)
+		promotion.Annotations = map[string]string{clientv1alpha1.ApprovedByAnnotation: "jane"}
+		c := newFakeClient(source, target, newSchema(), promotion)
+
+		result, promotion := reconcilePromotion(c)
+		Expect(result.RequeueAfter).NotTo(BeZero())
+		Expect(promotion.Status.Phase).NotTo(Equal(clientv1alpha1.PromotionPhasePromoted))
+		Expect(events(promotion)).To(ContainElement(clientv1alpha1.PromotionEventFailed))
+		Expect(registries.registry(target).activeVersions(subject)).To(Equal([]int32{1}))
+		Expect(registries.registry(target).subjectCompatibility(subject)).To(Equal("BACKWARD"))
+	})
+
+	It("should fail the promotion of a version which does not exist in the source", func() {
+		c := newFakeClient(source, target, newSchema(), newPromotion(5))
+
+		result, promotion := reconcilePromotion(c)
+		Expect(result.RequeueAfter).NotTo(BeZero())
+		Expect(promotion.Status.Ready).To(BeFalse())
+		Expect(promotion.Status.Phase).To(Equal(clientv1alpha1.PromotionPhasePending))
+		Expect(promotion.Status.Message).To(ContainSubstring(source.Name))
+		Expect(events(promotion)).To(Equal([]string{clientv1alpha1.PromotionEventFailed}))
+		Expect(registries.registry(target).activeVersions(subject)).To(BeEmpty())
+	})
+
+	It("should leave a paused target Schema Registry untouched", func() {
+		target.Annotations = map[string]string{clientv1alpha1.PausedAnnotation: "true"}
+		c := newFakeClient(source, target, newSchema(), newPromotion(1))
+
+		result, promotion := reconcilePromotion(c)
+		Expect(result.RequeueAfter).NotTo(BeZero())
+		Expect(promotion.Status.Message).To(Equal("Schema Registry sr-production is paused"))
+		Expect(registries.registry(target).activeVersions(subject)).To(BeEmpty())
+	})
+
+	It("should wait for the target Schema Registry to be ready", func() {
+		target.Status.Ready = false
+		c := newFakeClient(source, target, newSchema(), newPromotion(1))
+
+		result, promotion := reconcilePromotion(c)
+		Expect(result.RequeueAfter).NotTo(BeZero())
+		Expect(promotion.Status.Message).To(Equal("Schema Registry sr-production is not ready"))
+		Expect(registries.registry(target).activeVersions(subject)).To(BeEmpty())
+	})
+})
@@ -25,51 +25,88 @@ SOFTWARE.
 package controller
 
 import (
+	"bufio"
 	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	clientv1alpha1 "github.com/steffen-karlsson/schema-registry-operator/api/v1alpha1"
+	"github.com/steffen-karlsson/schema-registry-operator/pkg/hash"
 	k8s_manager "github.com/steffen-karlsson/schema-registry-operator/pkg/k8s"
 )
 
 const (
-	SchemaRegistryPodName         = "schema-registry-server"
-	SchemaRegistryHttpPort        = 8082
-	SchemaRegistryHttpsPort       = 8081
-	SchemaRegistryHttpPortName    = "sr-http"
-	PrometheusExporterPodName     = "prometheus-jmx-exporter"
-	PrometheusExporterPodImage    = "bitnami/jmx-exporter:1.1.0"
-	PrometheusConfigMapNameSuffix = "jmx-config"
-	JmxConfigMapFileName          = "jmx-schema-registry-prometheus.yml"
-	JmxConfigMapContent           = `jmxUrl: service:jmx:rmi:///jndi/rmi://localhost:5555/jmxrmi
+	SchemaRegistryPodName            = "schema-registry-server"
+	SchemaRegistryHttpPort           = 8082
+	SchemaRegistryHttpsPort          = 8081
+	SchemaRegistryHttpPortName       = "sr-http"
+	SchemaRegistryPaused             = "Reconciliation is paused"
+	SchemaRegistryChildrenPruned     = "ChildrenPruned"
+	SchemaRegistryDeploymentReplaced = "DeploymentReplaced"
+	SchemaRegistryReadNameSuffix     = "read"
+	SchemaRegistryLeaderMetric       = "kafka_schema_registry_master_slave_role"
+	ReplicasHandOverFieldManager     = k8s_manager.FieldManager + "-replicas-handover"
+	PrometheusExporterPodName        = "prometheus-jmx-exporter"
+	PrometheusExporterPodImage       = "bitnami/jmx-exporter:1.1.0"
+	PrometheusConfigMapNameSuffix    = "jmx-config"
+	JmxConfigMapFileName             = "jmx-schema-registry-prometheus.yml"
+	JmxConfigMapContent              = `jmxUrl: service:jmx:rmi:///jndi/rmi://localhost:5555/jmxrmi
 lowercaseOutputName: true
 lowercaseOutputLabelNames: true
 ssl: false`
 )
 
+const (
+	SchemaRegistryProbePeriodSeconds      = 10
+	SchemaRegistryProbeTimeoutSeconds     = 5
+	SchemaRegistryProbeFailureThreshold   = 3
+	SchemaRegistryStartupFailureThreshold = 30
+	SchemaRegistryTargetCPUUtilization    = 80
+)
+
 // SchemaRegistryReconciler reconciles a SchemaRegistry object
 type SchemaRegistryReconciler struct {
 	k8s_manager.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// APIReader reads the Secrets and ConfigMaps directly from the API server, as only the ones with the watch
+	// label are cached
+	APIReader client.Reader
 }
 
 // +kubebuilder:rbac:groups=client.sroperator.io,resources=schemaregistries,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=client.sroperator.io,resources=schemaregistries/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=client.sroperator.io,resources=schemaregistries/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -91,6 +128,7 @@ func (r *SchemaRegistryReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			// If the custom resource is not found then it usually means that it was deleted or not created
 			// In this way, we will stop the reconciliation
 			logger.Info("schema registry resource not found. Ignoring since object must be deleted")
+			orphanedSubjects.DeleteLabelValues(req.Namespace, req.Name)
 			return ctrl.Result{}, nil
 		}
 
@@ -100,26 +138,92 @@ func (r *SchemaRegistryReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return ctrl.Result{}, err
 	}
 
-	// The purpose is to create a deployment for the SchemaRegistry
-	deployment := &appsv1.Deployment{}
-	err = r.Get(ctx, types.NamespacedName{Name: schemaRegistry.Name, Namespace: schemaRegistry.Namespace}, deployment)
-	isNotFound := apierrors.IsNotFound(err)
+	// The purpose is to leave the deployment and the schema registry untouched while the reconciliation is paused
+	if clientv1alpha1.IsPaused(schemaRegistry.ObjectMeta) {
+		logger.Info("schema registry reconciliation is paused")
+		if schemaRegistry.Status.Paused {
+			return ctrl.Result{}, nil
+		}
+
+		schemaRegistry.Status.Paused = true
+		schemaRegistry.Status.Message = SchemaRegistryPaused
+		return ctrl.Result{}, r.Status().Update(ctx, schemaRegistry)
+	}
+	schemaRegistry.Status.Paused = false
 
-	if err != nil && !isNotFound {
-		logger.Error(err, "failed to get deployment")
-		return ctrl.Result{}, err
+	if request := clientv1alpha1.ForceReconcileRequest(schemaRegistry.ObjectMeta); request != schemaRegistry.Status.LastForceReconcile {
+		logger.Info("forced reconciliation requested", "Request", request)
+		schemaRegistry.Status.LastForceReconcile = request
 	}
 
-	if err = r.deploySchemaRegistry(ctx, schemaRegistry, !isNotFound, logger); err != nil {
+	// The purpose is to leave the deployment untouched rather than silently overriding the managed config
+	err = schemaRegistry.ValidateConfig()
+	schemaRegistry.SetConfigValid(err)
+	if err != nil {
+		logger.Error(err, "invalid config")
+		schemaRegistry.Status.Ready = false
+		schemaRegistry.Status.Message = "Invalid config: " + err.Error()
+		if err = r.Status().Update(ctx, schemaRegistry); err != nil {
+			logger.Error(err, "failed to update schema registry status")
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+
+	// The purpose is to read the readiness from the deployment as returned by the apply,
+	// and as the deployment is owned, changes to its status trigger a reconciliation
+	deployments, err := r.deploySchemaRegistry(ctx, schemaRegistry, logger)
+	if err != nil {
 		return ctrl.Result{}, err
 	}
 
-	if deployment.Spec.Replicas != nil && (deployment.Status.ReadyReplicas == *deployment.Spec.Replicas) {
-		schemaRegistry.Status.Ready = true
-		schemaRegistry.Status.Message = "Schema Registry is ready"
-	} else {
+	// The purpose is to only report the schema registry as ready once its API is reachable,
+	// as the replicas can be ready while the schema registry is not able to serve requests
+	switch {
+	case slices.ContainsFunc(deployments, func(deployment *appsv1.Deployment) bool {
+		return !isDeploymentReady(deployment)
+	}):
+		schemaRegistry.SetHealthUnknown()
 		schemaRegistry.Status.Ready = false
 		schemaRegistry.Status.Message = "Schema Registry is not ready"
+	case schemaRegistry.CheckHealth(ctx, logger) != nil:
+		schemaRegistry.Status.Ready = false
+		schemaRegistry.Status.Message = "Schema Registry API is not reachable"
+	default:
+		schemaRegistry.Status.Ready = true
+		schemaRegistry.Status.Message = "Schema Registry is ready"
+	}
+
+	// The global config and mode can only be applied through the REST API once the schema registry is ready
+	if schemaRegistry.Status.Ready {
+		if err = schemaRegistry.SyncGlobalConfig(ctx, logger); err != nil {
+			logger.Error(err, "failed to sync global config")
+			schemaRegistry.Status.Message = "Failed to apply global config to Schema Registry: " + err.Error()
+		}
+
+		if err = r.reconcileOrphanedSubjects(ctx, schemaRegistry, logger); err != nil {
+			logger.Error(err, "failed to reconcile orphaned subjects")
+			schemaRegistry.Status.Message = "Failed to reconcile orphaned subjects: " + err.Error()
+		}
+	}
+
+	switch {
+	case !schemaRegistry.Spec.Metrics.Enabled:
+		schemaRegistry.SetLeaderUnknown(clientv1alpha1.SchemaRegistryReasonMetricsDisabled,
+			"The leader is only reported when metrics are enabled")
+	case !schemaRegistry.Status.Ready:
+		schemaRegistry.SetLeaderUnknown(clientv1alpha1.SchemaRegistryReasonDeploymentNotReady,
+			"The deployment of the Schema Registry is not ready")
+	default:
+		leader, err := r.findLeader(ctx, schemaRegistry)
+		if err != nil {
+			logger.Error(err, "failed to find leader")
+			schemaRegistry.SetLeaderUnknown(clientv1alpha1.SchemaRegistryReasonMetricsUnreadable, err.Error())
+			break
+		}
+
+		schemaRegistry.SetLeader(leader)
 	}
 
 	if err = r.Status().Update(ctx, schemaRegistry); err != nil {
@@ -130,66 +234,246 @@ func (r *SchemaRegistryReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	return ctrl.Result{RequeueAfter: time.Minute}, nil
 }
 
-func (r *SchemaRegistryReconciler) deploySchemaRegistry(
+// reconcileOrphanedSubjects reports the subjects not owned by any resource in the status and as a metric,
+// and prunes them when the prune policy is enabled
+func (r *SchemaRegistryReconciler) reconcileOrphanedSubjects(
 	ctx context.Context,
 	schemaRegistry *clientv1alpha1.SchemaRegistry,
-	exists bool,
 	logger logr.Logger,
 ) error {
-	configMap := r.createSchemaRegistryConfigMap(schemaRegistry)
-	if err := ctrl.SetControllerReference(schemaRegistry, configMap, r.Scheme); err != nil {
-		logger.Error(err, "failed to set controller reference", "configmap", configMap)
+	orphaned, err := schemaRegistry.FindOrphanedSubjects(ctx, r, logger)
+	if err != nil {
 		return err
 	}
 
-	if err := r.Upsert(ctx, configMap, exists); err != nil {
-		logger.Error(err, "failed to create deployment", "configmap", configMap)
-		return err
+	if schemaRegistry.Spec.OrphanedSubjects.Policy == clientv1alpha1.OrphanedSubjectsPolicyPrune && len(orphaned) > 0 {
+		orphaned, err = schemaRegistry.PruneOrphanedSubjects(ctx, orphaned, logger)
 	}
 
-	deployment := r.createSchemaRegistryDeployment(schemaRegistry)
-	if err := ctrl.SetControllerReference(schemaRegistry, deployment, r.Scheme); err != nil {
-		logger.Error(err, "failed to set controller reference", "deployment", deployment)
-		return err
+	schemaRegistry.Status.OrphanedSubjects = orphaned
+	orphanedSubjects.WithLabelValues(schemaRegistry.Namespace, schemaRegistry.Name).Set(float64(len(orphaned)))
+
+	return err
+}
+
+// deploySchemaRegistry applies the desired children of the SchemaRegistry independently of each other,
+// prunes the owned children which are no longer desired, and returns the applied deployments
+func (r *SchemaRegistryReconciler) deploySchemaRegistry(
+	ctx context.Context,
+	schemaRegistry *clientv1alpha1.SchemaRegistry,
+	logger logr.Logger,
+) ([]*appsv1.Deployment, error) {
+	deployments := []*appsv1.Deployment{r.createSchemaRegistryDeployment(schemaRegistry)}
+	if schemaRegistry.Spec.ReadReplicas.Replicas > 0 {
+		deployments = append(deployments, r.createSchemaRegistryReadDeployment(schemaRegistry))
 	}
 
-	if err := r.Upsert(ctx, deployment, exists); err != nil {
-		logger.Error(err, "failed to create deployment", "deployment", deployment)
-		return err
+	for _, deployment := range deployments {
+		if err := r.replaceOnSelectorChange(ctx, schemaRegistry, deployment, logger); err != nil {
+			logger.Error(err, "failed to replace deployment with a changed selector", "child", deployment.Name)
+			return nil, err
+		}
 	}
 
-	service := r.createSchemaRegistryService(schemaRegistry)
-	if err := ctrl.SetControllerReference(schemaRegistry, service, r.Scheme); err != nil {
-		logger.Error(err, "failed to set controller reference", "service", service)
-		return err
+	if err := r.handOverReplicas(ctx, schemaRegistry, deployments[0], logger); err != nil {
+		logger.Error(err, "failed to hand over the replicas to the horizontal pod autoscaler")
+		return nil, err
 	}
 
-	if err := r.Upsert(ctx, service, exists); err != nil {
-		logger.Error(err, "failed to create service", "service", service)
-		return err
+	var desired []client.Object
+	if schemaRegistry.Spec.Metrics.Enabled {
+		desired = append(desired, r.createSchemaRegistryConfigMap(schemaRegistry))
+	}
+
+	// The purpose is to roll the pods when the config or the referenced Secrets and ConfigMaps change
+	for _, deployment := range deployments {
+		checksum, err := r.getConfigChecksum(ctx, schemaRegistry, deployment)
+		if err != nil {
+			logger.Error(err, "failed to compute config checksum", "child", deployment.Name)
+			return nil, err
+		}
+
+		deployment.Spec.Template.Annotations = withDefaults(deployment.Spec.Template.Annotations, map[string]string{
+			clientv1alpha1.ConfigChecksumAnnotation: checksum,
+		})
+		desired = append(desired, deployment)
 	}
 
+	desired = append(desired, r.createSchemaRegistryService(schemaRegistry))
+	if schemaRegistry.Spec.ReadReplicas.Replicas > 0 && schemaRegistry.Spec.ReadReplicas.Service {
+		desired = append(desired, r.createSchemaRegistryReadService(schemaRegistry))
+	}
 	if schemaRegistry.Spec.Ingress.Enabled {
-		ingress := r.createSchemaRegistryIngress(schemaRegistry)
-		if err := ctrl.SetControllerReference(schemaRegistry, ingress, r.Scheme); err != nil {
-			logger.Error(err, "failed to set controller reference", "ingress", ingress)
+		desired = append(desired, r.createSchemaRegistryIngress(schemaRegistry))
+	}
+	if schemaRegistry.Spec.Autoscaling != nil {
+		desired = append(desired, r.createSchemaRegistryHorizontalPodAutoscaler(schemaRegistry))
+	}
+	if schemaRegistry.MaxReplicas() > 1 && schemaRegistry.Spec.Availability.PodDisruptionBudget.Enabled {
+		desired = append(desired, r.createSchemaRegistryPodDisruptionBudget(schemaRegistry))
+	}
+
+	for _, child := range desired {
+		if err := ctrl.SetControllerReference(schemaRegistry, child, r.Scheme); err != nil {
+			logger.Error(err, "failed to set controller reference", "child", child.GetName())
+			return nil, err
+		}
+
+		if err := r.Apply(ctx, child); err != nil {
+			logger.Error(err, "failed to apply child", "kind", child.GetObjectKind().GroupVersionKind().Kind,
+				"child", child.GetName())
+			return nil, err
+		}
+	}
+
+	if err := r.pruneSchemaRegistryChildren(ctx, schemaRegistry, desired, logger); err != nil {
+		logger.Error(err, "failed to prune children")
+		return nil, err
+	}
+
+	return deployments, nil
+}
+
+// pruneSchemaRegistryChildren deletes the children controlled by the SchemaRegistry which are not desired,
+// such as the Ingress once it is disabled, and lists the pruned children in an event
+func (r *SchemaRegistryReconciler) pruneSchemaRegistryChildren(
+	ctx context.Context,
+	schemaRegistry *clientv1alpha1.SchemaRegistry,
+	desired []client.Object,
+	logger logr.Logger,
+) error {
+	isDesired := make(map[string]bool, len(desired))
+	for _, child := range desired {
+		isDesired[fmt.Sprintf("%T/%s", child, child.GetName())] = true
+	}
+
+	var pruned []string
+	for _, list := range []client.ObjectList{
+		&corev1.ConfigMapList{},
+		&appsv1.DeploymentList{},
+		&corev1.ServiceList{},
+		&networkingv1.IngressList{},
+		&policyv1.PodDisruptionBudgetList{},
+		&autoscalingv2.HorizontalPodAutoscalerList{},
+	} {
+		// The purpose is to also see the ConfigMaps created before they were labeled to be watched, as only
+		// the labeled ones are cached
+		reader := client.Reader(r)
+		if _, ok := list.(*corev1.ConfigMapList); ok {
+			reader = r.APIReader
+		}
+
+		if err := reader.List(ctx, list, client.InNamespace(schemaRegistry.Namespace)); err != nil {
 			return err
 		}
 
-		if err := r.Upsert(ctx, ingress, exists); err != nil {
-			logger.Error(err, "failed to create ingress", "ingress", ingress)
+		items, err := meta.ExtractList(list)
+		if err != nil {
 			return err
 		}
+
+		for _, item := range items {
+			child, ok := item.(client.Object)
+			if !ok || !metav1.IsControlledBy(child, schemaRegistry) || isDesired[fmt.Sprintf("%T/%s", child, child.GetName())] {
+				continue
+			}
+
+			gvk, err := apiutil.GVKForObject(child, r.Scheme)
+			if err != nil {
+				return err
+			}
+
+			logger.Info("Pruning child which is no longer desired", "kind", gvk.Kind, "child", child.GetName())
+			if err = r.Delete(ctx, child); client.IgnoreNotFound(err) != nil {
+				return err
+			}
+			pruned = append(pruned, gvk.Kind+"/"+child.GetName())
+		}
+	}
+
+	if len(pruned) > 0 {
+		r.Recorder.Event(schemaRegistry, corev1.EventTypeNormal, SchemaRegistryChildrenPruned,
+			"Pruned children which are no longer desired: "+strings.Join(pruned, ", "))
 	}
 
 	return nil
 }
 
-func (r *SchemaRegistryReconciler) createSchemaRegistryDeployment(sr *clientv1alpha1.SchemaRegistry) *appsv1.Deployment {
-	objectMeta := metav1.ObjectMeta{
-		Labels: r.getSchemaRegistryLabels(sr),
+// replaceOnSelectorChange deletes the existing deployment when its selector differs from the desired one, as the
+// selector is immutable, so the deployment is created again with the desired selector when it is applied. This is
+// the case for the deployments created before the leader eligible pods were labeled with their role.
+func (r *SchemaRegistryReconciler) replaceOnSelectorChange(
+	ctx context.Context,
+	sr *clientv1alpha1.SchemaRegistry,
+	deployment *appsv1.Deployment,
+	logger logr.Logger,
+) error {
+	current := &appsv1.Deployment{}
+	err := r.Get(ctx, types.NamespacedName{Name: deployment.Name, Namespace: deployment.Namespace}, current)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if !metav1.IsControlledBy(current, sr) || current.Spec.Selector == nil ||
+		maps.Equal(current.Spec.Selector.MatchLabels, deployment.Spec.Selector.MatchLabels) {
+		return nil
+	}
+
+	logger.Info("Replacing deployment with a changed selector", "child", current.Name)
+	if err = r.Delete(ctx, current, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+		return err
+	}
+
+	r.Recorder.Event(sr, corev1.EventTypeNormal, SchemaRegistryDeploymentReplaced,
+		"Replaced deployment "+current.Name+" as its selector changed")
+
+	return nil
+}
+
+// handOverReplicas keeps the replicas of the deployment when they are left to the horizontal pod autoscaler.
+// The replicas are reset to 1 when the operator stops applying them while it is their only owner, so the current
+// replicas are first applied by a separate field manager which keeps owning them until the autoscaler takes over.
+// A new deployment starts with the minimum replicas, which are handed over by the next reconciliation.
+func (r *SchemaRegistryReconciler) handOverReplicas(
+	ctx context.Context,
+	sr *clientv1alpha1.SchemaRegistry,
+	deployment *appsv1.Deployment,
+	logger logr.Logger,
+) error {
+	if sr.Spec.Autoscaling == nil {
+		return nil
+	}
+
+	current := &appsv1.Deployment{}
+	err := r.Get(ctx, types.NamespacedName{Name: deployment.Name, Namespace: deployment.Namespace}, current)
+	switch {
+	case apierrors.IsNotFound(err):
+		deployment.Spec.Replicas = ptr.To(ptr.Deref(sr.Spec.Autoscaling.MinReplicas, 1))
+		return nil
+	case err != nil:
+		return err
 	}
 
+	if current.Spec.Replicas == nil || !k8s_manager.IsFieldAppliedBy(current, k8s_manager.FieldManager, "spec", "replicas") {
+		return nil
+	}
+
+	logger.Info("Handing over the replicas to the horizontal pod autoscaler", "Replicas", *current.Spec.Replicas)
+	return r.ApplyAs(ctx, &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deployment.Name,
+			Namespace: deployment.Namespace,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: current.Spec.Replicas,
+		},
+	}, ReplicasHandOverFieldManager)
+}
+
+// getSchemaRegistryEnvs returns the env of the schema registry container. The env rendered by the operator is
+// overridden by the additional config, which is overridden by the config and finally by the debug flag. As
+// ValidateConfig rejects such overrides beforehand, the precedence only keeps the env free of duplicates.
+func (r *SchemaRegistryReconciler) getSchemaRegistryEnvs(sr *clientv1alpha1.SchemaRegistry) []corev1.EnvVar {
 	envs := []corev1.EnvVar{
 		{
 			Name: "SCHEMA_REGISTRY_HOST_NAME",
@@ -235,18 +519,10 @@ func (r *SchemaRegistryReconciler) createSchemaRegistryDeployment(sr *clientv1al
 			Name:      "SCHEMA_REGISTRY_KAFKASTORE_SASL_JAAS_CONFIG",
 			ValueFrom: sr.Spec.KafkaConfig.Authentication.SaslJaasConfig.Source,
 		},
-		{
-			Name:  "SCHEMA_REGISTRY_SCHEMA_COMPATIBILITY_LEVEL",
-			Value: sr.Spec.CompatibilityLevel,
-		},
 	}
 
-	for _, additionalConfig := range sr.Spec.AdditionalConfig {
-		envs = append(envs, corev1.EnvVar{
-			Name:  additionalConfig.Name,
-			Value: additionalConfig.Value,
-		})
-	}
+	envs = append(envs, sr.Spec.AdditionalConfig...)
+	envs = append(envs, sr.ConfigEnvs()...)
 
 	if sr.Spec.Debug {
 		envs = append(envs, corev1.EnvVar{
@@ -255,6 +531,36 @@ func (r *SchemaRegistryReconciler) createSchemaRegistryDeployment(sr *clientv1al
 		})
 	}
 
+	return dedupeEnvs(envs)
+}
+
+// dedupeEnvs keeps the last env of each name at the position of the first, as the env is applied as a list
+// keyed by name which rejects duplicate names, while a later duplicate overrides the earlier ones in a container
+func dedupeEnvs(envs []corev1.EnvVar) []corev1.EnvVar {
+	index := make(map[string]int, len(envs))
+	deduped := make([]corev1.EnvVar, 0, len(envs))
+	for _, env := range envs {
+		if i, ok := index[env.Name]; ok {
+			deduped[i] = env
+			continue
+		}
+
+		index[env.Name] = len(deduped)
+		deduped = append(deduped, env)
+	}
+
+	return deduped
+}
+
+func (r *SchemaRegistryReconciler) createSchemaRegistryDeployment(sr *clientv1alpha1.SchemaRegistry) *appsv1.Deployment {
+	podTemplate := sr.Spec.PodTemplate
+	objectMeta := metav1.ObjectMeta{
+		Labels:      withDefaults(podTemplate.Labels, r.getSchemaRegistryPrimaryLabels(sr)),
+		Annotations: podTemplate.Annotations,
+	}
+
+	envs := r.getSchemaRegistryEnvs(sr)
+
 	containers := []corev1.Container{
 		{
 			Name:            SchemaRegistryPodName,
@@ -267,18 +573,23 @@ func (r *SchemaRegistryReconciler) createSchemaRegistryDeployment(sr *clientv1al
 					Protocol:      corev1.ProtocolTCP,
 				},
 			},
-			Resources: *sr.Spec.Resources,
-			Env:       envs,
+			Resources:       *sr.Spec.Resources,
+			Env:             envs,
+			StartupProbe:    newSchemaRegistryProbe("/", SchemaRegistryStartupFailureThreshold),
+			LivenessProbe:   newSchemaRegistryProbe("/", SchemaRegistryProbeFailureThreshold),
+			ReadinessProbe:  newSchemaRegistryProbe("/v1/metadata/id", SchemaRegistryProbeFailureThreshold),
+			SecurityContext: podTemplate.ContainerSecurityContext,
+			VolumeMounts:    podTemplate.VolumeMounts,
 		},
 	}
 
 	var volumes []corev1.Volume
 
 	if sr.Spec.Metrics.Enabled {
-		objectMeta.Annotations = map[string]string{
+		objectMeta.Annotations = withDefaults(podTemplate.Annotations, map[string]string{
 			"prometheus.io/scrape": "true",
 			"prometheus.io/port":   strconv.Itoa(int(sr.Spec.Metrics.Port)),
-		}
+		})
 
 		volumes = append(volumes, corev1.Volume{
 			Name: PrometheusConfigMapNameSuffix,
@@ -313,27 +624,168 @@ func (r *SchemaRegistryReconciler) createSchemaRegistryDeployment(sr *clientv1al
 		})
 	}
 
+	affinity, topologySpreadConstraints := r.getSchemaRegistrySpread(sr)
+
+	// The replicas are left to the horizontal pod autoscaler when autoscaling is defined, see handOverReplicas
+	replicas := &sr.Spec.Replicas
+	if sr.Spec.Autoscaling != nil {
+		replicas = nil
+	}
+
 	return &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      sr.Name,
 			Namespace: sr.Namespace,
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: &sr.Spec.Replicas,
+			Replicas: replicas,
+			Strategy: ptr.Deref(sr.Spec.Availability.Strategy, appsv1.DeploymentStrategy{}),
 			Selector: &metav1.LabelSelector{
-				MatchLabels: r.getSchemaRegistryLabels(sr),
+				MatchLabels: r.getSchemaRegistryPrimaryLabels(sr),
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: objectMeta,
 				Spec: corev1.PodSpec{
-					Containers: containers,
-					Volumes:    volumes,
+					Containers:                append(containers, podTemplate.Sidecars...),
+					Volumes:                   append(volumes, podTemplate.Volumes...),
+					NodeSelector:              podTemplate.NodeSelector,
+					Tolerations:               podTemplate.Tolerations,
+					Affinity:                  affinity,
+					TopologySpreadConstraints: topologySpreadConstraints,
+					SecurityContext:           podTemplate.SecurityContext,
+					ServiceAccountName:        podTemplate.ServiceAccountName,
+					ImagePullSecrets:          podTemplate.ImagePullSecrets,
+					PriorityClassName:         podTemplate.PriorityClassName,
 				},
 			},
 		},
 	}
 }
 
+// getConfigChecksum computes the checksum of the rendered env of the schema registry container, the referenced
+// Secret and ConfigMap data, and the JMX config
+func (r *SchemaRegistryReconciler) getConfigChecksum(
+	ctx context.Context,
+	sr *clientv1alpha1.SchemaRegistry,
+	deployment *appsv1.Deployment,
+) (string, error) {
+	var config strings.Builder
+	for _, env := range deployment.Spec.Template.Spec.Containers[0].Env {
+		fmt.Fprintf(&config, "%s=%s\n", env.Name, env.Value)
+		if env.ValueFrom == nil {
+			continue
+		}
+
+		value, err := r.getEnvSourceValue(ctx, sr.Namespace, env.ValueFrom)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&config, "%s<-%s\n", env.Name, value)
+	}
+
+	if sr.Spec.Metrics.Enabled {
+		config.WriteString(JmxConfigMapContent)
+	}
+
+	checksum, err := hash.Hash(config.String())
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%08x", checksum), nil
+}
+
+// getEnvSourceValue returns the value of the Secret or ConfigMap key referenced by the env source, which is empty
+// when the Secret or ConfigMap does not exist yet, and labels the Secret or ConfigMap to be watched
+func (r *SchemaRegistryReconciler) getEnvSourceValue(
+	ctx context.Context,
+	namespace string,
+	source *corev1.EnvVarSource,
+) (string, error) {
+	switch {
+	case source.SecretKeyRef != nil:
+		secret := &corev1.Secret{}
+		err := r.APIReader.Get(ctx, types.NamespacedName{Name: source.SecretKeyRef.Name, Namespace: namespace}, secret)
+		if err != nil {
+			return "", client.IgnoreNotFound(err)
+		}
+		return string(secret.Data[source.SecretKeyRef.Key]), r.watchEnvSource(ctx, secret)
+	case source.ConfigMapKeyRef != nil:
+		configMap := &corev1.ConfigMap{}
+		err := r.APIReader.Get(ctx, types.NamespacedName{Name: source.ConfigMapKeyRef.Name, Namespace: namespace}, configMap)
+		if err != nil {
+			return "", client.IgnoreNotFound(err)
+		}
+		return configMap.Data[source.ConfigMapKeyRef.Key], r.watchEnvSource(ctx, configMap)
+	default:
+		return "", nil
+	}
+}
+
+// watchEnvSource labels a referenced Secret or ConfigMap to be watched, as only the labeled ones are cached,
+// so changing it rolls the pods right away instead of on the next periodic reconciliation
+func (r *SchemaRegistryReconciler) watchEnvSource(ctx context.Context, obj client.Object) error {
+	if obj.GetLabels()[clientv1alpha1.WatchLabelName] == clientv1alpha1.WatchLabelValue {
+		return nil
+	}
+
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	obj.SetLabels(withDefaults(obj.GetLabels(), map[string]string{
+		clientv1alpha1.WatchLabelName: clientv1alpha1.WatchLabelValue,
+	}))
+
+	return r.Patch(ctx, obj, patch)
+}
+
+// findSchemaRegistriesForEnvSource maps a Secret or ConfigMap to the SchemaRegistries referencing it in their env
+func (r *SchemaRegistryReconciler) findSchemaRegistriesForEnvSource(ctx context.Context, obj client.Object) []reconcile.Request {
+	schemaRegistries := &clientv1alpha1.SchemaRegistryList{}
+	if err := r.List(ctx, schemaRegistries, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list schema registries")
+		return nil
+	}
+
+	_, isSecret := obj.(*corev1.Secret)
+
+	var requests []reconcile.Request
+	for i := range schemaRegistries.Items {
+		sr := &schemaRegistries.Items[i]
+		if slices.ContainsFunc(r.getSchemaRegistryEnvs(sr), func(env corev1.EnvVar) bool {
+			switch {
+			case env.ValueFrom == nil:
+				return false
+			case isSecret:
+				return env.ValueFrom.SecretKeyRef != nil && env.ValueFrom.SecretKeyRef.Name == obj.GetName()
+			default:
+				return env.ValueFrom.ConfigMapKeyRef != nil && env.ValueFrom.ConfigMapKeyRef.Name == obj.GetName()
+			}
+		}) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sr)})
+		}
+	}
+
+	return requests
+}
+
+// createSchemaRegistryReadDeployment creates the deployment of the read replicas, which share the pod template of
+// the leader eligible replicas but are never eligible as leader
+func (r *SchemaRegistryReconciler) createSchemaRegistryReadDeployment(sr *clientv1alpha1.SchemaRegistry) *appsv1.Deployment {
+	deployment := r.createSchemaRegistryDeployment(sr)
+	deployment.Name = sr.Name + "-" + SchemaRegistryReadNameSuffix
+	deployment.Spec.Replicas = &sr.Spec.ReadReplicas.Replicas
+	deployment.Spec.Selector.MatchLabels = r.getSchemaRegistryReadLabels(sr)
+	maps.Copy(deployment.Spec.Template.Labels, r.getSchemaRegistryReadLabels(sr))
+
+	envs := deployment.Spec.Template.Spec.Containers[0].Env
+	for i := range envs {
+		if envs[i].Name == "SCHEMA_REGISTRY_MASTER_ELIGIBILITY" {
+			envs[i].Value = "false"
+		}
+	}
+
+	return deployment
+}
+
 func (r *SchemaRegistryReconciler) createSchemaRegistryService(sr *clientv1alpha1.SchemaRegistry) *corev1.Service {
 	ports := []corev1.ServicePort{
 		{
@@ -361,6 +813,15 @@ func (r *SchemaRegistryReconciler) createSchemaRegistryService(sr *clientv1alpha
 	}
 }
 
+// createSchemaRegistryReadService creates the read-only service, selecting only the read replicas
+func (r *SchemaRegistryReconciler) createSchemaRegistryReadService(sr *clientv1alpha1.SchemaRegistry) *corev1.Service {
+	service := r.createSchemaRegistryService(sr)
+	service.Name = sr.Name + "-" + SchemaRegistryReadNameSuffix
+	service.Spec.Selector = r.getSchemaRegistryReadLabels(sr)
+
+	return service
+}
+
 func (r *SchemaRegistryReconciler) createSchemaRegistryIngress(sr *clientv1alpha1.SchemaRegistry) *networkingv1.Ingress {
 	ingres := &networkingv1.Ingress{
 		ObjectMeta: metav1.ObjectMeta{
@@ -411,6 +872,10 @@ func (r *SchemaRegistryReconciler) createSchemaRegistryConfigMap(sr *clientv1alp
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      sr.Name + "-" + PrometheusConfigMapNameSuffix,
 			Namespace: sr.Namespace,
+			// The config map is labeled to be cached, so it is watched as an owned child
+			Labels: map[string]string{
+				clientv1alpha1.WatchLabelName: clientv1alpha1.WatchLabelValue,
+			},
 		},
 		Data: map[string]string{
 			JmxConfigMapFileName: JmxConfigMapContent,
@@ -418,15 +883,242 @@ func (r *SchemaRegistryReconciler) createSchemaRegistryConfigMap(sr *clientv1alp
 	}
 }
 
+// createSchemaRegistryPodDisruptionBudget creates the pod disruption budget, allowing one pod to be unavailable
+// unless configured otherwise
+func (r *SchemaRegistryReconciler) createSchemaRegistryPodDisruptionBudget(
+	sr *clientv1alpha1.SchemaRegistry,
+) *policyv1.PodDisruptionBudget {
+	budget := sr.Spec.Availability.PodDisruptionBudget
+	maxUnavailable := budget.MaxUnavailable
+	if budget.MinAvailable == nil && maxUnavailable == nil {
+		maxUnavailable = ptr.To(intstr.FromInt32(1))
+	}
+
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      sr.Name,
+			Namespace: sr.Namespace,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable:   budget.MinAvailable,
+			MaxUnavailable: maxUnavailable,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: r.getSchemaRegistryPrimaryLabels(sr),
+			},
+		},
+	}
+}
+
+// createSchemaRegistryHorizontalPodAutoscaler creates the horizontal pod autoscaler of the deployment, scaling on
+// the CPU utilization and the custom metric when defined
+func (r *SchemaRegistryReconciler) createSchemaRegistryHorizontalPodAutoscaler(
+	sr *clientv1alpha1.SchemaRegistry,
+) *autoscalingv2.HorizontalPodAutoscaler {
+	autoscaling := sr.Spec.Autoscaling
+	targetCPUUtilization := autoscaling.TargetCPUUtilizationPercentage
+	if targetCPUUtilization == nil && autoscaling.Metric == nil {
+		targetCPUUtilization = ptr.To(int32(SchemaRegistryTargetCPUUtilization))
+	}
+
+	var metrics []autoscalingv2.MetricSpec
+	if targetCPUUtilization != nil {
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: corev1.ResourceCPU,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: targetCPUUtilization,
+				},
+			},
+		})
+	}
+	if autoscaling.Metric != nil {
+		metrics = append(metrics, *autoscaling.Metric)
+	}
+
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      sr.Name,
+			Namespace: sr.Namespace,
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: appsv1.SchemeGroupVersion.String(),
+				Kind:       "Deployment",
+				Name:       sr.Name,
+			},
+			MinReplicas: autoscaling.MinReplicas,
+			MaxReplicas: autoscaling.MaxReplicas,
+			Metrics:     metrics,
+		},
+	}
+}
+
+// getSchemaRegistrySpread returns the affinity and topology spread constraints of the pods, the ones defined in
+// the pod template take precedence over the default spread, which only applies when running more than one replica
+func (r *SchemaRegistryReconciler) getSchemaRegistrySpread(
+	sr *clientv1alpha1.SchemaRegistry,
+) (*corev1.Affinity, []corev1.TopologySpreadConstraint) {
+	podTemplate := sr.Spec.PodTemplate
+	if podTemplate.Affinity != nil || len(podTemplate.TopologySpreadConstraints) > 0 || sr.MaxReplicas() <= 1 {
+		return podTemplate.Affinity, podTemplate.TopologySpreadConstraints
+	}
+
+	selector := &metav1.LabelSelector{
+		MatchLabels: r.getSchemaRegistryPrimaryLabels(sr),
+	}
+
+	switch sr.Spec.Availability.Spread {
+	case clientv1alpha1.AvailabilitySpreadAntiAffinity:
+		return &corev1.Affinity{
+			PodAntiAffinity: &corev1.PodAntiAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+					{
+						Weight: 100,
+						PodAffinityTerm: corev1.PodAffinityTerm{
+							LabelSelector: selector,
+							TopologyKey:   sr.Spec.Availability.TopologyKey,
+						},
+					},
+				},
+			},
+		}, nil
+	case clientv1alpha1.AvailabilitySpreadTopologySpread:
+		return nil, []corev1.TopologySpreadConstraint{
+			{
+				MaxSkew:           1,
+				TopologyKey:       sr.Spec.Availability.TopologyKey,
+				WhenUnsatisfiable: corev1.ScheduleAnyway,
+				LabelSelector:     selector,
+			},
+		}
+	default:
+		return nil, nil
+	}
+}
+
+// withDefaults merges the defaults of the operator into the given values, the defaults take precedence
+func withDefaults(values map[string]string, defaults map[string]string) map[string]string {
+	merged := make(map[string]string, len(values)+len(defaults))
+	maps.Copy(merged, values)
+	maps.Copy(merged, defaults)
+
+	return merged
+}
+
+// newSchemaRegistryProbe creates a probe calling the given path of the schema registry API.
+// The cluster ID path is served from the Kafka store, so it fails while Kafka is not reachable.
+func newSchemaRegistryProbe(path string, failureThreshold int32) *corev1.Probe {
+	return &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path: path,
+				Port: intstr.FromString(SchemaRegistryHttpPortName),
+			},
+		},
+		PeriodSeconds:    SchemaRegistryProbePeriodSeconds,
+		TimeoutSeconds:   SchemaRegistryProbeTimeoutSeconds,
+		FailureThreshold: failureThreshold,
+	}
+}
+
+// isDeploymentReady checks if the deployment has rolled out its latest generation and all replicas are ready
+func isDeploymentReady(deployment *appsv1.Deployment) bool {
+	return deployment.Spec.Replicas != nil &&
+		deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.ReadyReplicas == *deployment.Spec.Replicas
+}
+
 func (r *SchemaRegistryReconciler) getSchemaRegistryLabels(sr *clientv1alpha1.SchemaRegistry) map[string]string {
 	return map[string]string{
 		"app": sr.Name,
 	}
 }
 
+// getSchemaRegistryPrimaryLabels returns the labels of the leader eligible pods, which are distinct from the read
+// replicas so the selectors of the deployments, the pod disruption budget and the spread do not overlap
+func (r *SchemaRegistryReconciler) getSchemaRegistryPrimaryLabels(sr *clientv1alpha1.SchemaRegistry) map[string]string {
+	return withDefaults(r.getSchemaRegistryLabels(sr), map[string]string{
+		clientv1alpha1.RoleLabelName: clientv1alpha1.RolePrimary,
+	})
+}
+
+func (r *SchemaRegistryReconciler) getSchemaRegistryReadLabels(sr *clientv1alpha1.SchemaRegistry) map[string]string {
+	return withDefaults(r.getSchemaRegistryLabels(sr), map[string]string{
+		clientv1alpha1.RoleLabelName: clientv1alpha1.RoleRead,
+	})
+}
+
+// findLeader returns the name of the leader eligible pod reporting itself as leader through the metrics exporter,
+// or an empty name when no pod reports itself as leader
+func (r *SchemaRegistryReconciler) findLeader(ctx context.Context, sr *clientv1alpha1.SchemaRegistry) (string, error) {
+	pods := &corev1.PodList{}
+	err := r.List(ctx, pods, client.InNamespace(sr.Namespace), client.MatchingLabels(r.getSchemaRegistryPrimaryLabels(sr)))
+	if err != nil {
+		return "", err
+	}
+
+	httpClient := &http.Client{Timeout: SchemaRegistryProbeTimeoutSeconds * time.Second}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" {
+			continue
+		}
+
+		leader, err := isLeader(ctx, httpClient, fmt.Sprintf("http://%s:%d/metrics", pod.Status.PodIP, sr.Spec.Metrics.Port))
+		if err != nil {
+			return "", fmt.Errorf("failed to read metrics of pod %s: %w", pod.Name, err)
+		}
+		if leader {
+			return pod.Name, nil
+		}
+	}
+
+	return "", nil
+}
+
+// isLeader checks if the metrics exposed at the url report the schema registry as leader
+func isLeader(ctx context.Context, httpClient *http.Client, url string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || !strings.HasPrefix(fields[0], SchemaRegistryLeaderMetric) {
+			continue
+		}
+
+		value, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return false, err
+		}
+
+		return value == 1, nil
+	}
+
+	return false, scanner.Err()
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *SchemaRegistryReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&clientv1alpha1.SchemaRegistry{}).
+		Owns(&appsv1.Deployment{}).
+		Owns(&corev1.Service{}).
+		Owns(&corev1.ConfigMap{}).
+		Owns(&networkingv1.Ingress{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.findSchemaRegistriesForEnvSource)).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.findSchemaRegistriesForEnvSource)).
 		Complete(r)
 }
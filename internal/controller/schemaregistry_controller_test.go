@@ -26,17 +26,26 @@ package controller
 
 import (
 	"context"
+	"slices"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	clientv1alpha1 "github.com/steffen-karlsson/schema-registry-operator/api/v1alpha1"
 	k8s_manager "github.com/steffen-karlsson/schema-registry-operator/pkg/k8s"
+	"github.com/steffen-karlsson/schema-registry-operator/pkg/srclient"
 )
 
 var _ = Describe("SchemaRegistry Controller", func() {
@@ -78,8 +87,10 @@ var _ = Describe("SchemaRegistry Controller", func() {
 		It("should successfully reconcile the resource", func() {
 			By("Reconciling the created resource")
 			controllerReconciler := &SchemaRegistryReconciler{
-				Client: *k8s_manager.NewClient(k8sClient),
-				Scheme: k8sClient.Scheme(),
+				Client:    *k8s_manager.NewClient(k8sClient),
+				Scheme:    k8sClient.Scheme(),
+				Recorder:  record.NewFakeRecorder(10),
+				APIReader: k8sClient,
 			}
 
 			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
@@ -91,3 +102,432 @@ var _ = Describe("SchemaRegistry Controller", func() {
 		})
 	})
 })
+
+var _ = Describe("SchemaRegistry orphaned subjects", func() {
+	var (
+		ctx            context.Context
+		registries     *fakeRegistries
+		schemaRegistry *clientv1alpha1.SchemaRegistry
+		registry       *fakeRegistry
+	)
+
+	// newOwners returns a Schema, SchemaMirror and SchemaRegistryRestore owning a subject each
+	newOwners := func() []client.Object {
+		return []client.Object{
+			&clientv1alpha1.Schema{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "orders",
+					Namespace: "default",
+					Labels:    instanceLabels(schemaRegistry.Name),
+				},
+				Spec: clientv1alpha1.SchemaSpec{Subject: "orders", Target: "VALUE"},
+			},
+			&clientv1alpha1.SchemaMirror{
+				ObjectMeta: metav1.ObjectMeta{Name: "mirror", Namespace: "default"},
+				Spec: clientv1alpha1.SchemaMirrorSpec{
+					Destination: clientv1alpha1.MirrorEndpoint{Instance: schemaRegistry.Name},
+				},
+				Status: clientv1alpha1.SchemaMirrorStatus{
+					Subjects: []clientv1alpha1.SubjectMirrorStatus{{Name: "mirrored-value"}},
+				},
+			},
+			&clientv1alpha1.SchemaRegistryRestore{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "restore",
+					Namespace: "default",
+					Labels:    instanceLabels(schemaRegistry.Name),
+				},
+				Status: clientv1alpha1.SchemaRegistryRestoreStatus{
+					Subjects: []clientv1alpha1.SubjectRestoreStatus{{Name: "restored-value"}},
+				},
+			},
+		}
+	}
+
+	reconcileOrphanedSubjects := func(objects ...client.Object) error {
+		c := newFakeClient(append(objects, schemaRegistry)...)
+		controllerReconciler := &SchemaRegistryReconciler{
+			Client:    *c,
+			Scheme:    c.Scheme(),
+			Recorder:  record.NewFakeRecorder(10),
+			APIReader: c,
+		}
+
+		return controllerReconciler.reconcileOrphanedSubjects(ctx, schemaRegistry, log.FromContext(ctx))
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		registries = newFakeRegistries()
+		schemaRegistry = newReadySchemaRegistry("orphan-registry")
+
+		registry = registries.registry(schemaRegistry)
+		registry.register("customer-value", `{"type":"record","name":"Customer"}`)
+		registry.register("orders-value", `{"type":"record","name":"Order"}`, srclient.SchemaReference{
+			Name:    ptr.To("Customer"),
+			Subject: ptr.To("customer-value"),
+			Version: ptr.To(int32(1)),
+		})
+		registry.register("mirrored-value", `{"type":"string"}`)
+		registry.register("restored-value", `{"type":"int"}`)
+		registry.register("_confluent-telemetry", `{"type":"long"}`)
+		registry.register("stale-value", `{"type":"bytes"}`)
+	})
+
+	AfterEach(func() {
+		registries.Close()
+	})
+
+	It("should report subjects not owned by a Schema, mirror or restore", func() {
+		Expect(reconcileOrphanedSubjects(newOwners()...)).To(Succeed())
+		Expect(schemaRegistry.Status.OrphanedSubjects).To(Equal([]string{
+			"_confluent-telemetry", "customer-value", "stale-value",
+		}))
+		Expect(registry.activeVersions("stale-value")).To(Equal([]int32{1}))
+	})
+
+	It("should soft delete orphaned subjects which are not allow-listed or referenced", func() {
+		schemaRegistry.Spec.OrphanedSubjects = clientv1alpha1.SchemaRegistryOrphanedSubjects{
+			Policy:    clientv1alpha1.OrphanedSubjectsPolicyPrune,
+			AllowList: []string{"_confluent-.*"},
+		}
+
+		Expect(reconcileOrphanedSubjects(newOwners()...)).To(Succeed())
+		Expect(schemaRegistry.Status.OrphanedSubjects).To(Equal([]string{"_confluent-telemetry", "customer-value"}))
+		Expect(registry.activeVersions("stale-value")).To(BeEmpty())
+		Expect(registry.version("stale-value", 1)).NotTo(BeNil())
+		Expect(registry.activeVersions("customer-value")).To(Equal([]int32{1}))
+		Expect(registry.activeVersions("mirrored-value")).To(Equal([]int32{1}))
+		Expect(registry.activeVersions("restored-value")).To(Equal([]int32{1}))
+	})
+
+	It("should permanently delete orphaned subjects when enabled", func() {
+		schemaRegistry.Spec.OrphanedSubjects = clientv1alpha1.SchemaRegistryOrphanedSubjects{
+			Policy:    clientv1alpha1.OrphanedSubjectsPolicyPrune,
+			AllowList: []string{"_confluent-.*"},
+			Permanent: true,
+		}
+
+		Expect(reconcileOrphanedSubjects(newOwners()...)).To(Succeed())
+		Expect(registry.version("stale-value", 1)).To(BeNil())
+		Expect(registry.activeVersions("customer-value")).To(Equal([]int32{1}))
+	})
+})
+
+var _ = Describe("SchemaRegistry env", func() {
+	envValue := func(envs []corev1.EnvVar, name string) []string {
+		var values []string
+		for _, env := range envs {
+			if env.Name == name {
+				values = append(values, env.Value)
+			}
+		}
+
+		return values
+	}
+
+	It("should keep a single env per name, following the documented precedence", func() {
+		sr := newReadySchemaRegistry("test-sr")
+		sr.Spec.Debug = true
+		sr.Spec.AdditionalConfig = []corev1.EnvVar{
+			{Name: "SCHEMA_REGISTRY_KAFKASTORE_SECURITY_PROTOCOL", Value: "SASL_SSL"},
+			{Name: "SCHEMA_REGISTRY_KAFKASTORE_TOPIC", Value: "_schemas_additional"},
+			{Name: "SCHEMA_REGISTRY_DEBUG", Value: "false"},
+			{Name: "SCHEMA_REGISTRY_LOG4J_ROOT_LOGLEVEL", Value: "WARN"},
+		}
+		sr.Spec.Config = map[string]clientv1alpha1.SchemaRegistryConfigValue{
+			"kafkastore.topic": {Value: "_schemas_config"},
+		}
+
+		reconciler := &SchemaRegistryReconciler{}
+		envs := reconciler.getSchemaRegistryEnvs(sr)
+
+		Expect(envValue(envs, "SCHEMA_REGISTRY_KAFKASTORE_SECURITY_PROTOCOL")).To(Equal([]string{"SASL_SSL"}))
+		Expect(envValue(envs, "SCHEMA_REGISTRY_KAFKASTORE_TOPIC")).To(Equal([]string{"_schemas_config"}))
+		Expect(envValue(envs, "SCHEMA_REGISTRY_DEBUG")).To(Equal([]string{"true"}))
+		Expect(envValue(envs, "SCHEMA_REGISTRY_LOG4J_ROOT_LOGLEVEL")).To(Equal([]string{"WARN"}))
+
+		By("keeping the overridden env at the position of the env rendered by the operator")
+		var names []string
+		for _, env := range envs {
+			names = append(names, env.Name)
+		}
+		Expect(slices.Index(names, "SCHEMA_REGISTRY_KAFKASTORE_SECURITY_PROTOCOL")).To(BeNumerically("<",
+			slices.Index(names, "SCHEMA_REGISTRY_KAFKASTORE_SASL_MECHANISM")))
+	})
+})
+
+var _ = Describe("SchemaRegistry pod template", func() {
+	reconciler := &SchemaRegistryReconciler{}
+
+	// The spread only selects the leader eligible pods, not the read replicas
+	primaryLabels := map[string]string{"app": "test-sr", clientv1alpha1.RoleLabelName: clientv1alpha1.RolePrimary}
+
+	newSchemaRegistry := func(replicas int32, spread string) *clientv1alpha1.SchemaRegistry {
+		sr := newReadySchemaRegistry("test-sr")
+		sr.Spec.Replicas = replicas
+		sr.Spec.Availability.Spread = spread
+		sr.Spec.Availability.TopologyKey = "topology.kubernetes.io/zone"
+		return sr
+	}
+
+	It("should merge the defaults of the operator into the given values", func() {
+		merged := withDefaults(map[string]string{"app": "custom", "team": "platform"}, map[string]string{"app": "test-sr"})
+		Expect(merged).To(Equal(map[string]string{"app": "test-sr", "team": "platform"}))
+
+		Expect(withDefaults(nil, map[string]string{"app": "test-sr"})).To(Equal(map[string]string{"app": "test-sr"}))
+		Expect(withDefaults(map[string]string{"team": "platform"}, nil)).To(Equal(map[string]string{"team": "platform"}))
+	})
+
+	It("should not modify the given values", func() {
+		values := map[string]string{"app": "custom"}
+		withDefaults(values, map[string]string{"app": "test-sr"})
+		Expect(values).To(Equal(map[string]string{"app": "custom"}))
+	})
+
+	It("should not spread a single replica", func() {
+		affinity, constraints := reconciler.getSchemaRegistrySpread(
+			newSchemaRegistry(1, clientv1alpha1.AvailabilitySpreadAntiAffinity))
+		Expect(affinity).To(BeNil())
+		Expect(constraints).To(BeEmpty())
+	})
+
+	It("should spread the replicas with pod anti-affinity", func() {
+		affinity, constraints := reconciler.getSchemaRegistrySpread(
+			newSchemaRegistry(3, clientv1alpha1.AvailabilitySpreadAntiAffinity))
+		Expect(constraints).To(BeEmpty())
+		Expect(affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution).To(HaveLen(1))
+
+		term := affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0].PodAffinityTerm
+		Expect(term.TopologyKey).To(Equal("topology.kubernetes.io/zone"))
+		Expect(term.LabelSelector.MatchLabels).To(Equal(primaryLabels))
+	})
+
+	It("should spread the replicas with a topology spread constraint", func() {
+		affinity, constraints := reconciler.getSchemaRegistrySpread(
+			newSchemaRegistry(3, clientv1alpha1.AvailabilitySpreadTopologySpread))
+		Expect(affinity).To(BeNil())
+		Expect(constraints).To(HaveLen(1))
+		Expect(constraints[0].TopologyKey).To(Equal("topology.kubernetes.io/zone"))
+		Expect(constraints[0].WhenUnsatisfiable).To(Equal(corev1.ScheduleAnyway))
+		Expect(constraints[0].LabelSelector.MatchLabels).To(Equal(primaryLabels))
+	})
+
+	It("should not spread the replicas when disabled", func() {
+		affinity, constraints := reconciler.getSchemaRegistrySpread(
+			newSchemaRegistry(3, clientv1alpha1.AvailabilitySpreadNone))
+		Expect(affinity).To(BeNil())
+		Expect(constraints).To(BeEmpty())
+	})
+
+	It("should spread the maximum replicas of the autoscaler", func() {
+		sr := newSchemaRegistry(1, clientv1alpha1.AvailabilitySpreadTopologySpread)
+		sr.Spec.Autoscaling = &clientv1alpha1.SchemaRegistryAutoscaling{MaxReplicas: 4}
+
+		_, constraints := reconciler.getSchemaRegistrySpread(sr)
+		Expect(constraints).To(HaveLen(1))
+	})
+
+	It("should prefer the spread of the pod template", func() {
+		sr := newSchemaRegistry(3, clientv1alpha1.AvailabilitySpreadAntiAffinity)
+		sr.Spec.PodTemplate.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{
+			{MaxSkew: 2, TopologyKey: "kubernetes.io/hostname", WhenUnsatisfiable: corev1.DoNotSchedule},
+		}
+
+		affinity, constraints := reconciler.getSchemaRegistrySpread(sr)
+		Expect(affinity).To(BeNil())
+		Expect(constraints).To(Equal(sr.Spec.PodTemplate.TopologySpreadConstraints))
+	})
+})
+
+var _ = Describe("SchemaRegistry read replicas", func() {
+	reconciler := &SchemaRegistryReconciler{}
+
+	It("should not overlap the selectors of the primary and read deployments", func() {
+		sr := newReadySchemaRegistry("test-sr")
+		sr.Spec.Replicas = 2
+		sr.Spec.ReadReplicas.Replicas = 3
+
+		primary := reconciler.createSchemaRegistryDeployment(sr)
+		read := reconciler.createSchemaRegistryReadDeployment(sr)
+
+		Expect(primary.Spec.Selector.MatchLabels).To(HaveKeyWithValue(clientv1alpha1.RoleLabelName, clientv1alpha1.RolePrimary))
+		Expect(primary.Spec.Template.Labels).To(HaveKeyWithValue(clientv1alpha1.RoleLabelName, clientv1alpha1.RolePrimary))
+		Expect(read.Spec.Selector.MatchLabels).To(HaveKeyWithValue(clientv1alpha1.RoleLabelName, clientv1alpha1.RoleRead))
+		Expect(read.Spec.Template.Labels).To(HaveKeyWithValue(clientv1alpha1.RoleLabelName, clientv1alpha1.RoleRead))
+
+		By("selecting the primary and read pods with the service")
+		service := reconciler.createSchemaRegistryService(sr)
+		Expect(service.Spec.Selector).To(Equal(map[string]string{"app": "test-sr"}))
+	})
+
+	It("should only count the primary pods in the pod disruption budget", func() {
+		sr := newReadySchemaRegistry("test-sr")
+		sr.Spec.Replicas = 2
+		sr.Spec.ReadReplicas.Replicas = 3
+
+		budget := reconciler.createSchemaRegistryPodDisruptionBudget(sr)
+		Expect(budget.Spec.Selector.MatchLabels).To(HaveKeyWithValue(clientv1alpha1.RoleLabelName, clientv1alpha1.RolePrimary))
+	})
+})
+
+var _ = Describe("SchemaRegistry referenced config", func() {
+	ctx := context.Background()
+
+	It("should watch an unlabeled referenced Secret and roll the pods when it changes", func() {
+		sr := newReadySchemaRegistry("test-sr")
+		sr.Spec.KafkaConfig.Authentication.SaslJaasConfig.Source = &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "sasl-credentials"},
+				Key:                  "jaas",
+			},
+		}
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "sasl-credentials", Namespace: "default"},
+			Data:       map[string][]byte{"jaas": []byte("user=a password=1")},
+		}
+		c := newFakeClient(sr, secret)
+		reconciler := &SchemaRegistryReconciler{Client: *c, Scheme: c.Scheme(), APIReader: c}
+
+		deployment := reconciler.createSchemaRegistryDeployment(sr)
+		checksum, err := reconciler.getConfigChecksum(ctx, sr, deployment)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("labeling the Secret to be watched")
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(secret), secret)).To(Succeed())
+		Expect(secret.Labels).To(HaveKeyWithValue(clientv1alpha1.WatchLabelName, clientv1alpha1.WatchLabelValue))
+		Expect(reconciler.findSchemaRegistriesForEnvSource(ctx, secret)).To(ConsistOf(
+			reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sr)}))
+
+		By("rotating the credentials")
+		secret.Data["jaas"] = []byte("user=a password=2")
+		Expect(c.Update(ctx, secret)).To(Succeed())
+
+		rotated, err := reconciler.getConfigChecksum(ctx, sr, deployment)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rotated).NotTo(Equal(checksum))
+	})
+
+	It("should prune a controlled ConfigMap which is not labeled to be watched", func() {
+		sr := newReadySchemaRegistry("test-sr")
+		sr.UID = "test-sr-uid"
+		configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-sr-legacy", Namespace: "default"}}
+		Expect(controllerutil.SetControllerReference(sr, configMap, newFakeClient().Scheme())).To(Succeed())
+		c := newFakeClient(sr, configMap)
+		reconciler := &SchemaRegistryReconciler{
+			Client:    *c,
+			Scheme:    c.Scheme(),
+			Recorder:  record.NewFakeRecorder(10),
+			APIReader: c,
+		}
+
+		Expect(reconciler.pruneSchemaRegistryChildren(ctx, sr, nil, log.FromContext(ctx))).To(Succeed())
+		Expect(errors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(configMap), configMap))).To(BeTrue())
+	})
+})
+
+var _ = Describe("SchemaRegistry pause", func() {
+	ctx := context.Background()
+
+	reconcileSchemaRegistry := func(c *k8s_manager.Client, sr *clientv1alpha1.SchemaRegistry) *clientv1alpha1.SchemaRegistry {
+		controllerReconciler := &SchemaRegistryReconciler{
+			Client:    *c,
+			Scheme:    c.Scheme(),
+			Recorder:  record.NewFakeRecorder(10),
+			APIReader: c,
+		}
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(sr)})
+		Expect(err).NotTo(HaveOccurred())
+
+		reconciled := &clientv1alpha1.SchemaRegistry{}
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(sr), reconciled)).To(Succeed())
+
+		return reconciled
+	}
+
+	It("should leave the deployment untouched while paused", func() {
+		sr := newReadySchemaRegistry("test-sr")
+		sr.Annotations = map[string]string{clientv1alpha1.PausedAnnotation: "true"}
+		c := newFakeClient(sr)
+
+		sr = reconcileSchemaRegistry(c, sr)
+		Expect(sr.Status.Paused).To(BeTrue())
+		Expect(sr.Status.Message).To(Equal(SchemaRegistryPaused))
+
+		deployments := &appsv1.DeploymentList{}
+		Expect(c.List(ctx, deployments, client.InNamespace("default"))).To(Succeed())
+		Expect(deployments.Items).To(BeEmpty())
+	})
+
+	It("should record a forced reconciliation", func() {
+		sr := newReadySchemaRegistry("test-sr")
+		sr.Annotations = map[string]string{clientv1alpha1.ForceReconcileAnnotation: "1700000000"}
+		// The invalid config stops the reconciliation before the deployment is applied, which the fake client does not support
+		sr.Spec.Config = map[string]clientv1alpha1.SchemaRegistryConfigValue{"listeners": {Value: "http://0.0.0.0:8081"}}
+		c := newFakeClient(sr)
+
+		sr = reconcileSchemaRegistry(c, sr)
+		Expect(sr.Status.Paused).To(BeFalse())
+		Expect(sr.Status.LastForceReconcile).To(Equal("1700000000"))
+	})
+})
+
+var _ = Describe("SchemaRegistry global config", func() {
+	var (
+		ctx        context.Context
+		registries *fakeRegistries
+		sr         *clientv1alpha1.SchemaRegistry
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		registries = newFakeRegistries()
+		sr = newReadySchemaRegistry("config-registry")
+		sr.Spec.CompatibilityLevel = "FULL"
+		sr.Spec.Normalize = true
+		sr.Spec.Mode = "IMPORT"
+	})
+
+	AfterEach(func() {
+		registries.Close()
+	})
+
+	It("should force the switch to IMPORT mode while the registry contains schemas", func() {
+		registry := registries.registry(sr)
+		registry.register("orders-value", `"string"`)
+
+		Expect(sr.SyncGlobalConfig(ctx, log.FromContext(ctx))).To(Succeed())
+		Expect(registry.compatibility).To(Equal("FULL"))
+		Expect(registry.normalize).To(BeTrue())
+		Expect(registry.mode).To(Equal("IMPORT"))
+		Expect(sr.Status.CompatibilityLevel).To(Equal("FULL"))
+		Expect(sr.Status.Normalize).To(BeTrue())
+		Expect(sr.Status.Mode).To(Equal("IMPORT"))
+		Expect(registry.configUpdates).To(Equal(1))
+		Expect(registry.modeUpdates).To(Equal(1))
+	})
+
+	It("should not update the registry when the config and mode are unchanged", func() {
+		registry := registries.registry(sr)
+		registry.compatibility = "FULL"
+		registry.normalize = true
+		registry.mode = "IMPORT"
+
+		Expect(sr.SyncGlobalConfig(ctx, log.FromContext(ctx))).To(Succeed())
+		Expect(registry.configUpdates).To(BeZero())
+		Expect(registry.modeUpdates).To(BeZero())
+		Expect(sr.Status.CompatibilityLevel).To(Equal("FULL"))
+		Expect(sr.Status.Normalize).To(BeTrue())
+		Expect(sr.Status.Mode).To(Equal("IMPORT"))
+	})
+
+	It("should leave the mode of the registry untouched when no mode is set", func() {
+		sr.Spec.Mode = ""
+		registry := registries.registry(sr)
+
+		Expect(sr.SyncGlobalConfig(ctx, log.FromContext(ctx))).To(Succeed())
+		Expect(registry.modeUpdates).To(BeZero())
+		Expect(sr.Status.Mode).To(Equal("READWRITE"))
+	})
+})
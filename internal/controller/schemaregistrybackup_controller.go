@@ -0,0 +1,511 @@
+/*
+MIT License
+
+Copyright (c) 2025 Steffen Karlsson
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	clientv1alpha1 "github.com/steffen-karlsson/schema-registry-operator/api/v1alpha1"
+	"github.com/steffen-karlsson/schema-registry-operator/pkg/archive"
+	k8s_manager "github.com/steffen-karlsson/schema-registry-operator/pkg/k8s"
+)
+
+const (
+	BackupCompletedSuccess    = "Backup completed successfully"
+	BackupArchiveChunkSize    = 900 * 1024
+	BackupArchiveFileSuffix   = ".json.gz"
+	BackupJobContainerName    = "archive-writer"
+	BackupJobImage            = "busybox:1.36"
+	BackupJobMountPath        = "/backup"
+	BackupJobStagingMountPath = "/staging"
+	BackupJobTTLSeconds       = 3600
+)
+
+// SchemaRegistryBackupReconciler reconciles a SchemaRegistryBackup object
+type SchemaRegistryBackupReconciler struct {
+	k8s_manager.Client
+	Scheme *runtime.Scheme
+	// APIReader lists the archive ConfigMaps directly from the API server, as they are not cached
+	APIReader client.Reader
+}
+
+// +kubebuilder:rbac:groups=client.sroperator.io,resources=schemaregistrybackups,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=client.sroperator.io,resources=schemaregistrybackups/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=client.sroperator.io,resources=schemaregistrybackups/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+// the SchemaRegistryBackup object against the actual cluster state, and then
+// perform operations to make the cluster state reflect the state specified by
+// the user.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.19.0/pkg/reconcile
+func (r *SchemaRegistryBackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	logger.Info("Reconciling SchemaRegistryBackup: ", "Name", req.Name, "Namespace", req.Namespace)
+
+	backup := &clientv1alpha1.SchemaRegistryBackup{}
+	err := r.Get(ctx, req.NamespacedName, backup)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("schema registry backup resource not found. Ignoring since object must be deleted")
+			return ctrl.Result{}, nil
+		}
+
+		logger.Error(err, "failed to get schema registry backup")
+		return ctrl.Result{}, err
+	}
+
+	// The purpose is to follow up on the job writing the latest archive to the PersistentVolumeClaim
+	if backup.Spec.Storage.Type == clientv1alpha1.BackupStoragePersistentVolumeClaim && backup.Status.LatestArchive != "" {
+		if err = r.updateArchiveJobStatus(ctx, backup); err != nil {
+			logger.Error(err, "failed to get archive job status")
+			return ctrl.Result{}, err
+		}
+	}
+
+	nextBackupTime, err := backup.NextBackupTime()
+	if err != nil {
+		logger.Error(err, "failed to determine next backup time")
+		backup.UpdateStatus(false, "Invalid schedule: "+backup.Spec.Schedule)
+		return ctrl.Result{}, r.Status().Update(ctx, backup)
+	}
+
+	if nextBackupTime == nil {
+		return ctrl.Result{}, r.Status().Update(ctx, backup)
+	}
+
+	if untilNextBackup := time.Until(*nextBackupTime); untilNextBackup > 0 {
+		backup.Status.NextBackupTime = &metav1.Time{Time: *nextBackupTime}
+		if err = r.Status().Update(ctx, backup); err != nil {
+			logger.Error(err, "failed to update schema registry backup status")
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{RequeueAfter: untilNextBackup}, nil
+	}
+
+	// The purpose is to get the SchemaRegistry instance
+	schemaRegistry := &clientv1alpha1.SchemaRegistry{}
+	err = schemaRegistry.NewInstance(ctx, r, backup.ObjectMeta, backup)
+	switch {
+	case errors.Is(err, clientv1alpha1.ErrInstanceLabelNotFound) || errors.Is(err, clientv1alpha1.ErrInstanceNotFound):
+		logger.Info("schema registry instance not found")
+
+		if err = r.Status().Update(ctx, backup); err != nil {
+			logger.Error(err, "failed to update schema registry backup status")
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	case err != nil:
+		logger.Error(err, "failed to get schema registry instance")
+		return ctrl.Result{}, err
+	}
+
+	if !schemaRegistry.Status.Ready {
+		backup.UpdateStatus(false, "Schema Registry "+schemaRegistry.Name+" is not ready")
+		if err = r.Status().Update(ctx, backup); err != nil {
+			logger.Error(err, "failed to update schema registry backup status")
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+
+	return r.BackupReconciler(ctx, backup, schemaRegistry, logger)
+}
+
+// BackupReconciler exports the schema registry and stores the archive
+func (r *SchemaRegistryBackupReconciler) BackupReconciler(
+	ctx context.Context,
+	backup *clientv1alpha1.SchemaRegistryBackup,
+	schemaRegistry *clientv1alpha1.SchemaRegistry,
+	logger logr.Logger,
+) (ctrl.Result, error) {
+	logger.Info("Backing up Schema Registry: ", "Name", schemaRegistry.Name, "Namespace", schemaRegistry.Namespace)
+
+	exported, err := schemaRegistry.Export(ctx, backup.Spec.IncludeDeleted, logger)
+	if err != nil {
+		logger.Error(err, "failed to export schema registry")
+		backup.UpdateStatus(false, "Failed to export Schema Registry: "+schemaRegistry.Name)
+
+		if err = r.Status().Update(ctx, backup); err != nil {
+			logger.Error(err, "failed to update schema registry backup status")
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
+	data, err := archive.Encode(exported)
+	if err != nil {
+		logger.Error(err, "failed to encode archive")
+		return ctrl.Result{}, err
+	}
+
+	archiveName := backup.ArchiveName(exported.CreatedAt)
+	checksum := archive.Checksum(data)
+
+	switch backup.Spec.Storage.Type {
+	case clientv1alpha1.BackupStoragePersistentVolumeClaim:
+		err = r.writeArchiveToVolume(ctx, backup, archiveName, checksum, data)
+	default:
+		labels := getArchiveLabels(backup, archiveName)
+		err = writeArchiveToConfigMaps(ctx, r.Client, r.Scheme, backup, archiveName, labels, checksum, data)
+		if err == nil {
+			err = r.pruneArchiveConfigMaps(ctx, backup)
+		}
+	}
+
+	if err != nil {
+		logger.Error(err, "failed to store archive", "archive", archiveName)
+		backup.UpdateStatus(false, "Failed to store archive: "+archiveName)
+
+		if err = r.Status().Update(ctx, backup); err != nil {
+			logger.Error(err, "failed to update schema registry backup status")
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
+	backup.Status.LatestArchive = archiveName
+	backup.Status.Checksum = checksum
+	backup.Status.SubjectCount = len(exported.Subjects)
+	backup.Status.VersionCount = exported.VersionCount()
+	backup.Status.LastBackupTime = &metav1.Time{Time: exported.CreatedAt}
+	backup.Status.NextBackupTime = nil
+
+	if backup.Spec.Storage.Type == clientv1alpha1.BackupStoragePersistentVolumeClaim {
+		backup.UpdateStatus(false, "Writing archive "+archiveName+" to PersistentVolumeClaim "+backup.Spec.Storage.ClaimName)
+	} else {
+		backup.UpdateStatus(true, BackupCompletedSuccess)
+	}
+
+	nextBackupTime, err := backup.NextBackupTime()
+	if err == nil && nextBackupTime != nil {
+		backup.Status.NextBackupTime = &metav1.Time{Time: *nextBackupTime}
+	}
+
+	if err = r.Status().Update(ctx, backup); err != nil {
+		logger.Error(err, "failed to update schema registry backup status")
+		return ctrl.Result{}, err
+	}
+
+	if backup.Status.NextBackupTime == nil {
+		return ctrl.Result{}, nil
+	}
+
+	return ctrl.Result{RequeueAfter: time.Until(backup.Status.NextBackupTime.Time)}, nil
+}
+
+// writeArchiveToConfigMaps splits the archive into chunks and stores each chunk in a ConfigMap owned by the owner,
+// named after the given name. The chunks already written are deleted again when a chunk cannot be written, so no
+// partial archive is left behind.
+func writeArchiveToConfigMaps(
+	ctx context.Context,
+	c client.Client,
+	scheme *runtime.Scheme,
+	owner client.Object,
+	name string,
+	labels map[string]string,
+	checksum string,
+	data []byte,
+) error {
+	chunks := archive.Split(data, BackupArchiveChunkSize)
+	written := make([]*corev1.ConfigMap, 0, len(chunks))
+	for i, chunk := range chunks {
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name + "-" + strconv.Itoa(i),
+				Namespace: owner.GetNamespace(),
+				Labels:    labels,
+				Annotations: map[string]string{
+					clientv1alpha1.ArchiveChunkAnnotation:    strconv.Itoa(i) + "/" + strconv.Itoa(len(chunks)),
+					clientv1alpha1.ArchiveChecksumAnnotation: checksum,
+				},
+			},
+			BinaryData: map[string][]byte{
+				clientv1alpha1.ArchiveDataKey: chunk,
+			},
+		}
+
+		if err := ctrl.SetControllerReference(owner, configMap, scheme); err != nil {
+			return err
+		}
+
+		if err := c.Create(ctx, configMap); err != nil {
+			for _, chunk := range written {
+				if deleteErr := c.Delete(ctx, chunk); client.IgnoreNotFound(deleteErr) != nil {
+					log.FromContext(ctx).Error(deleteErr, "failed to delete partial archive chunk", "ConfigMap", chunk.Name)
+				}
+			}
+
+			return err
+		}
+		written = append(written, configMap)
+	}
+
+	return nil
+}
+
+// pruneArchiveConfigMaps deletes the ConfigMaps of all but the newest complete archives within the history limit,
+// and the ConfigMaps of incomplete archives, so an archive whose write failed never replaces a complete one
+func (r *SchemaRegistryBackupReconciler) pruneArchiveConfigMaps(
+	ctx context.Context,
+	backup *clientv1alpha1.SchemaRegistryBackup,
+) error {
+	configMaps := &corev1.ConfigMapList{}
+	if err := r.APIReader.List(ctx, configMaps,
+		client.InNamespace(backup.Namespace),
+		client.MatchingLabels{clientv1alpha1.BackupLabelName: clientv1alpha1.LabelValue(backup.Name)}); err != nil {
+		return err
+	}
+
+	// The archive name is taken from the ConfigMap name, as the label values may be shortened
+	archives := map[string][]corev1.ConfigMap{}
+	for _, configMap := range configMaps.Items {
+		name := configMap.Name[:max(strings.LastIndex(configMap.Name, "-"), 0)]
+		if !strings.HasPrefix(name, backup.Name+"-") {
+			continue
+		}
+		archives[name] = append(archives[name], configMap)
+	}
+
+	// Archive names end with the time they were taken, so sorting by name sorts them by age
+	names := make([]string, 0, len(archives))
+	var pruned []string
+	for name, configMaps := range archives {
+		if !clientv1alpha1.IsArchiveComplete(configMaps) {
+			pruned = append(pruned, name)
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(names)))
+
+	if len(names) > int(backup.Spec.HistoryLimit) {
+		pruned = append(pruned, names[backup.Spec.HistoryLimit:]...)
+	}
+
+	for _, name := range pruned {
+		for _, configMap := range archives[name] {
+			if err := r.Delete(ctx, &configMap); client.IgnoreNotFound(err) != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeArchiveToVolume stages the archive in ConfigMaps and starts a job which writes it to the PersistentVolumeClaim.
+// The staging ConfigMaps are owned by the job, so they are garbage collected together with the job, and carry the
+// staging label instead of the archive labels, so they are neither pruned nor restored as an archive.
+func (r *SchemaRegistryBackupReconciler) writeArchiveToVolume(
+	ctx context.Context,
+	backup *clientv1alpha1.SchemaRegistryBackup,
+	archiveName string,
+	checksum string,
+	data []byte,
+) error {
+	chunkCount := len(archive.Split(data, BackupArchiveChunkSize))
+	job := r.createArchiveJob(backup, archiveName, chunkCount)
+	if err := ctrl.SetControllerReference(backup, job, r.Scheme); err != nil {
+		return err
+	}
+
+	if err := r.Create(ctx, job); err != nil {
+		return err
+	}
+
+	labels := map[string]string{clientv1alpha1.ArchiveStagingLabelName: clientv1alpha1.LabelValue(archiveName)}
+	if err := writeArchiveToConfigMaps(ctx, r.Client, r.Scheme, job, archiveName, labels, checksum, data); err != nil {
+		// The purpose is to not leave the job waiting for the missing chunks, its staged chunks are garbage collected
+		deleteErr := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if client.IgnoreNotFound(deleteErr) != nil {
+			log.FromContext(ctx).Error(deleteErr, "failed to delete archive job", "Job", job.Name)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (r *SchemaRegistryBackupReconciler) createArchiveJob(
+	backup *clientv1alpha1.SchemaRegistryBackup,
+	archiveName string,
+	chunkCount int,
+) *batchv1.Job {
+	volumes := []corev1.Volume{
+		{
+			Name: "backup",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: backup.Spec.Storage.ClaimName,
+				},
+			},
+		},
+	}
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      "backup",
+			MountPath: BackupJobMountPath,
+		},
+	}
+
+	chunkPaths := make([]string, 0, chunkCount)
+	for i := 0; i < chunkCount; i++ {
+		name := "chunk-" + strconv.Itoa(i)
+		volumes = append(volumes, corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: archiveName + "-" + strconv.Itoa(i),
+					},
+				},
+			},
+		})
+
+		mountPath := BackupJobStagingMountPath + "/" + strconv.Itoa(i)
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      name,
+			MountPath: mountPath,
+			ReadOnly:  true,
+		})
+		chunkPaths = append(chunkPaths, mountPath+"/"+clientv1alpha1.ArchiveDataKey)
+	}
+
+	// Each backup writes to its own directory, so pruning never removes the archives of another backup
+	directory := BackupJobMountPath + "/" + backup.Name
+	target := directory + "/" + archiveName + BackupArchiveFileSuffix
+	script := fmt.Sprintf("set -e\nmkdir -p %s\ncat %s > %s.tmp\nmv %s.tmp %s\nls -1t %s/*%s | tail -n +%d | xargs -r rm -f\n",
+		directory, strings.Join(chunkPaths, " "), target, target, target,
+		directory, BackupArchiveFileSuffix, backup.Spec.HistoryLimit+1)
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clientv1alpha1.LabelValue(archiveName),
+			Namespace: backup.Namespace,
+			Labels:    getArchiveLabels(backup, archiveName),
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            ptr.To(int32(3)),
+			TTLSecondsAfterFinished: ptr.To(int32(BackupJobTTLSeconds)),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{
+						{
+							Name:         BackupJobContainerName,
+							Image:        BackupJobImage,
+							Command:      []string{"sh", "-c", script},
+							VolumeMounts: volumeMounts,
+						},
+					},
+					Volumes: volumes,
+				},
+			},
+		},
+	}
+}
+
+// updateArchiveJobStatus reflects the state of the job writing the latest archive in the backup status
+func (r *SchemaRegistryBackupReconciler) updateArchiveJobStatus(
+	ctx context.Context,
+	backup *clientv1alpha1.SchemaRegistryBackup,
+) error {
+	job := &batchv1.Job{}
+	err := r.Get(ctx, types.NamespacedName{
+		Name:      clientv1alpha1.LabelValue(backup.Status.LatestArchive),
+		Namespace: backup.Namespace,
+	}, job)
+	switch {
+	case apierrors.IsNotFound(err):
+		// The job has been cleaned up after it finished, so the status is already up-to-date
+		return nil
+	case err != nil:
+		return err
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		switch condition.Type {
+		case batchv1.JobComplete:
+			if !backup.Status.Ready {
+				backup.UpdateStatus(true, BackupCompletedSuccess)
+			}
+		case batchv1.JobFailed:
+			if backup.Status.Ready || !strings.HasPrefix(backup.Status.Message, "Failed") {
+				backup.UpdateStatus(false, "Failed to write archive "+backup.Status.LatestArchive+": "+condition.Message)
+			}
+		}
+	}
+
+	return nil
+}
+
+// getArchiveLabels returns the labels of the objects storing an archive, shortened to valid label values
+func getArchiveLabels(backup *clientv1alpha1.SchemaRegistryBackup, archiveName string) map[string]string {
+	return map[string]string{
+		clientv1alpha1.BackupLabelName:  clientv1alpha1.LabelValue(backup.Name),
+		clientv1alpha1.ArchiveLabelName: clientv1alpha1.LabelValue(archiveName),
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SchemaRegistryBackupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&clientv1alpha1.SchemaRegistryBackup{}).
+		Owns(&batchv1.Job{}).
+		Complete(r)
+}
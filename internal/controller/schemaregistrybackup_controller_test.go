@@ -0,0 +1,402 @@
+/*
+MIT License
+
+Copyright (c) 2025 Steffen Karlsson
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	clientv1alpha1 "github.com/steffen-karlsson/schema-registry-operator/api/v1alpha1"
+	"github.com/steffen-karlsson/schema-registry-operator/pkg/archive"
+	k8s_manager "github.com/steffen-karlsson/schema-registry-operator/pkg/k8s"
+)
+
+var _ = Describe("SchemaRegistryBackup Controller", func() {
+	const resourceName = "test-backup"
+
+	var (
+		ctx            context.Context
+		registries     *fakeRegistries
+		schemaRegistry *clientv1alpha1.SchemaRegistry
+	)
+
+	newBackup := func(name string, spec clientv1alpha1.SchemaRegistryBackupSpec) *clientv1alpha1.SchemaRegistryBackup {
+		return &clientv1alpha1.SchemaRegistryBackup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				Labels:            instanceLabels(schemaRegistry.Name),
+				CreationTimestamp: metav1.Now(),
+			},
+			Spec: spec,
+		}
+	}
+
+	reconcileBackup := func(c *k8s_manager.Client, name string) (reconcile.Result, *clientv1alpha1.SchemaRegistryBackup) {
+		controllerReconciler := &SchemaRegistryBackupReconciler{
+			Client:    *c,
+			Scheme:    c.Scheme(),
+			APIReader: c,
+		}
+
+		result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: name, Namespace: "default"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		backup := &clientv1alpha1.SchemaRegistryBackup{}
+		Expect(c.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, backup)).To(Succeed())
+		return result, backup
+	}
+
+	// loadArchive assembles the archive stored in the ConfigMaps labelled with the archive name
+	loadArchive := func(c client.Client, archiveName string) *archive.Archive {
+		configMaps := &corev1.ConfigMapList{}
+		Expect(c.List(ctx, configMaps, client.MatchingLabels{
+			clientv1alpha1.ArchiveLabelName: clientv1alpha1.LabelValue(archiveName),
+		})).To(Succeed())
+		Expect(configMaps.Items).NotTo(BeEmpty())
+
+		sort.Slice(configMaps.Items, func(i, j int) bool {
+			return configMaps.Items[i].Annotations[clientv1alpha1.ArchiveChunkAnnotation] <
+				configMaps.Items[j].Annotations[clientv1alpha1.ArchiveChunkAnnotation]
+		})
+
+		var data bytes.Buffer
+		for _, configMap := range configMaps.Items {
+			data.Write(configMap.BinaryData[clientv1alpha1.ArchiveDataKey])
+		}
+
+		decoded, err := archive.Decode(data.Bytes())
+		Expect(err).NotTo(HaveOccurred())
+		return decoded
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		registries = newFakeRegistries()
+		schemaRegistry = newReadySchemaRegistry("backup-registry")
+
+		registry := registries.registry(schemaRegistry)
+		registry.register("orders-value", `{"type":"string"}`)
+		registry.register("orders-value", `{"type":"int"}`)
+		registry.register("customers-value", `{"type":"long"}`)
+	})
+
+	AfterEach(func() {
+		registries.Close()
+	})
+
+	Context("When reconciling a backup without a schedule", func() {
+		It("should store the archive in ConfigMaps once", func() {
+			c := newFakeClient(schemaRegistry, newBackup(resourceName, clientv1alpha1.SchemaRegistryBackupSpec{
+				HistoryLimit: 3,
+				Storage:      clientv1alpha1.BackupStorage{Type: clientv1alpha1.BackupStorageConfigMap},
+			}))
+
+			result, backup := reconcileBackup(c, resourceName)
+			Expect(result).To(Equal(reconcile.Result{}))
+			Expect(backup.Status.Ready).To(BeTrue())
+			Expect(backup.Status.Message).To(Equal(BackupCompletedSuccess))
+			Expect(backup.Status.LatestArchive).To(HavePrefix(resourceName + "-"))
+			Expect(backup.Status.SubjectCount).To(Equal(2))
+			Expect(backup.Status.VersionCount).To(Equal(3))
+			Expect(backup.Status.NextBackupTime).To(BeNil())
+
+			exported := loadArchive(c, backup.Status.LatestArchive)
+			Expect(exported.Registry).To(Equal(schemaRegistry.Name))
+			Expect(exported.Subjects).To(HaveLen(2))
+
+			configMaps := archiveConfigMaps(ctx, c)
+			Expect(configMaps).To(HaveLen(1))
+			Expect(configMaps[0].Annotations).To(HaveKeyWithValue(clientv1alpha1.ArchiveChunkAnnotation, "0/1"))
+			Expect(configMaps[0].Annotations).To(HaveKeyWithValue(clientv1alpha1.ArchiveChecksumAnnotation, backup.Status.Checksum))
+			Expect(configMaps[0].OwnerReferences).To(HaveLen(1))
+			Expect(configMaps[0].OwnerReferences[0].Name).To(Equal(resourceName))
+
+			By("not taking another backup when reconciled again")
+			result, _ = reconcileBackup(c, resourceName)
+			Expect(result).To(Equal(reconcile.Result{}))
+			Expect(archiveConfigMaps(ctx, c)).To(HaveLen(1))
+		})
+
+		It("should wait for the Schema Registry to be ready", func() {
+			schemaRegistry.Status.Ready = false
+			c := newFakeClient(schemaRegistry, newBackup(resourceName, clientv1alpha1.SchemaRegistryBackupSpec{
+				HistoryLimit: 3,
+			}))
+
+			result, backup := reconcileBackup(c, resourceName)
+			Expect(result.RequeueAfter).To(Equal(time.Minute))
+			Expect(backup.Status.Ready).To(BeFalse())
+			Expect(backup.Status.Message).To(ContainSubstring("is not ready"))
+			Expect(archiveConfigMaps(ctx, c)).To(BeEmpty())
+		})
+	})
+
+	Context("When reconciling a scheduled backup", func() {
+		It("should wait until the next scheduled time", func() {
+			c := newFakeClient(schemaRegistry, newBackup(resourceName, clientv1alpha1.SchemaRegistryBackupSpec{
+				Schedule:     "0 0 * * *",
+				HistoryLimit: 3,
+			}))
+
+			result, backup := reconcileBackup(c, resourceName)
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+			Expect(result.RequeueAfter).To(BeNumerically("<=", 24*time.Hour))
+			Expect(backup.Status.NextBackupTime).NotTo(BeNil())
+			Expect(backup.Status.LatestArchive).To(BeEmpty())
+			Expect(archiveConfigMaps(ctx, c)).To(BeEmpty())
+		})
+
+		It("should take a backup when it is due and schedule the next one", func() {
+			backup := newBackup(resourceName, clientv1alpha1.SchemaRegistryBackupSpec{
+				Schedule:     "* * * * *",
+				HistoryLimit: 3,
+			})
+			backup.CreationTimestamp = metav1.NewTime(time.Now().Add(-2 * time.Minute))
+			c := newFakeClient(schemaRegistry, backup)
+
+			result, backup := reconcileBackup(c, resourceName)
+			Expect(backup.Status.Ready).To(BeTrue())
+			Expect(backup.Status.LatestArchive).NotTo(BeEmpty())
+			Expect(backup.Status.NextBackupTime).NotTo(BeNil())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+			Expect(result.RequeueAfter).To(BeNumerically("<=", time.Minute))
+		})
+
+		It("should report an invalid schedule", func() {
+			c := newFakeClient(schemaRegistry, newBackup(resourceName, clientv1alpha1.SchemaRegistryBackupSpec{
+				Schedule:     "not a schedule",
+				HistoryLimit: 3,
+			}))
+
+			_, backup := reconcileBackup(c, resourceName)
+			Expect(backup.Status.Ready).To(BeFalse())
+			Expect(backup.Status.Message).To(Equal("Invalid schedule: not a schedule"))
+		})
+	})
+
+	Context("When pruning archives", func() {
+		archiveChunk := func(backupName string, archiveName string, chunk string) *corev1.ConfigMap {
+			index, _, _ := strings.Cut(chunk, "/")
+			return &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      archiveName + "-" + index,
+					Namespace: "default",
+					Labels: map[string]string{
+						clientv1alpha1.BackupLabelName:  clientv1alpha1.LabelValue(backupName),
+						clientv1alpha1.ArchiveLabelName: clientv1alpha1.LabelValue(archiveName),
+					},
+					Annotations: map[string]string{clientv1alpha1.ArchiveChunkAnnotation: chunk},
+				},
+			}
+		}
+
+		archiveConfigMap := func(backupName string, archiveName string) *corev1.ConfigMap {
+			return archiveChunk(backupName, archiveName, "0/1")
+		}
+
+		It("should keep only the newest archives of the backup within the history limit", func() {
+			c := newFakeClient(schemaRegistry,
+				newBackup(resourceName, clientv1alpha1.SchemaRegistryBackupSpec{HistoryLimit: 2}),
+				archiveConfigMap(resourceName, resourceName+"-20240101-000000"),
+				archiveConfigMap(resourceName, resourceName+"-20240102-000000"),
+				archiveConfigMap(resourceName+"-eu", resourceName+"-eu-20240101-000000"),
+			)
+
+			_, backup := reconcileBackup(c, resourceName)
+			Expect(backup.Status.Ready).To(BeTrue())
+
+			var names []string
+			for _, configMap := range archiveConfigMaps(ctx, c) {
+				names = append(names, configMap.Name)
+			}
+			Expect(names).To(ConsistOf(
+				backup.Status.LatestArchive+"-0",
+				resourceName+"-20240102-000000-0",
+				resourceName+"-eu-20240101-000000-0",
+			))
+		})
+
+		It("should not count an incomplete archive toward the history limit", func() {
+			c := newFakeClient(schemaRegistry,
+				newBackup(resourceName, clientv1alpha1.SchemaRegistryBackupSpec{HistoryLimit: 2}),
+				archiveChunk(resourceName, resourceName+"-20240101-000000", "0/2"),
+				archiveChunk(resourceName, resourceName+"-20240101-000000", "1/2"),
+				archiveChunk(resourceName, resourceName+"-20240102-000000", "1/2"),
+			)
+
+			_, backup := reconcileBackup(c, resourceName)
+			Expect(backup.Status.Ready).To(BeTrue())
+
+			var names []string
+			for _, configMap := range archiveConfigMaps(ctx, c) {
+				names = append(names, configMap.Name)
+			}
+			Expect(names).To(ConsistOf(
+				backup.Status.LatestArchive+"-0",
+				resourceName+"-20240101-000000-0",
+				resourceName+"-20240101-000000-1",
+			))
+		})
+
+		It("should delete the chunks already written when a chunk cannot be written", func() {
+			// The random content does not compress, so the archive is split into more than one chunk
+			random := make([]byte, BackupArchiveChunkSize)
+			_, _ = rand.Read(random)
+			registries.registry(schemaRegistry).register("payload-value",
+				`{"type":"string","doc":"`+base64.StdEncoding.EncodeToString(random)+`"}`)
+
+			c := newInterceptedFakeClient(interceptor.Funcs{
+				Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					if strings.HasSuffix(obj.GetName(), "-1") {
+						return errors.New("exceeded quota")
+					}
+					return c.Create(ctx, obj, opts...)
+				},
+			}, schemaRegistry, newBackup(resourceName, clientv1alpha1.SchemaRegistryBackupSpec{HistoryLimit: 2}))
+
+			_, backup := reconcileBackup(c, resourceName)
+			Expect(archiveConfigMaps(ctx, c)).To(BeEmpty())
+			Expect(backup.Status.Ready).To(BeFalse())
+			Expect(backup.Status.Message).To(HavePrefix("Failed to store archive"))
+		})
+
+		It("should shorten the labels of backups with long names", func() {
+			name := strings.Repeat("long-backup-name-", 4) + "prod"
+			c := newFakeClient(schemaRegistry,
+				newBackup(name, clientv1alpha1.SchemaRegistryBackupSpec{HistoryLimit: 1}),
+				archiveConfigMap(name, name+"-20240101-000000"),
+			)
+
+			_, backup := reconcileBackup(c, name)
+			Expect(backup.Status.Ready).To(BeTrue())
+
+			configMaps := archiveConfigMaps(ctx, c)
+			Expect(configMaps).To(HaveLen(1))
+			Expect(configMaps[0].Name).To(Equal(backup.Status.LatestArchive + "-0"))
+			for _, value := range configMaps[0].Labels {
+				Expect(validation.IsValidLabelValue(value)).To(BeEmpty())
+			}
+		})
+	})
+
+	Context("When storing archives in a PersistentVolumeClaim", func() {
+		newVolumeBackup := func(name string) *clientv1alpha1.SchemaRegistryBackup {
+			return newBackup(name, clientv1alpha1.SchemaRegistryBackupSpec{
+				HistoryLimit: 2,
+				Storage: clientv1alpha1.BackupStorage{
+					Type:      clientv1alpha1.BackupStoragePersistentVolumeClaim,
+					ClaimName: "backups",
+				},
+			})
+		}
+
+		It("should start a job writing and pruning the archive in the directory of the backup", func() {
+			c := newFakeClient(schemaRegistry, newVolumeBackup(resourceName))
+
+			_, backup := reconcileBackup(c, resourceName)
+			Expect(backup.Status.Ready).To(BeFalse())
+			Expect(backup.Status.Message).To(HavePrefix("Writing archive " + backup.Status.LatestArchive))
+
+			job := &batchv1.Job{}
+			Expect(c.Get(ctx, types.NamespacedName{Name: backup.Status.LatestArchive, Namespace: "default"}, job)).To(Succeed())
+			Expect(job.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("backups"))
+
+			script := job.Spec.Template.Spec.Containers[0].Command[2]
+			Expect(script).To(ContainSubstring("mkdir -p /backup/" + resourceName + "\n"))
+			Expect(script).To(ContainSubstring("/backup/" + resourceName + "/" + backup.Status.LatestArchive + BackupArchiveFileSuffix))
+			Expect(script).To(ContainSubstring("ls -1t /backup/" + resourceName + "/*" + BackupArchiveFileSuffix + " | tail -n +3 "))
+
+			By("staging the archive in ConfigMaps which are not taken for an archive")
+			Expect(archiveConfigMaps(ctx, c)).To(BeEmpty())
+			staged := &corev1.ConfigMapList{}
+			Expect(c.List(ctx, staged, client.MatchingLabels{
+				clientv1alpha1.ArchiveStagingLabelName: backup.Status.LatestArchive,
+			})).To(Succeed())
+			Expect(staged.Items).To(HaveLen(1))
+			Expect(staged.Items[0].Labels).NotTo(HaveKey(clientv1alpha1.BackupLabelName))
+			Expect(staged.Items[0].OwnerReferences[0].Kind).To(Equal("Job"))
+
+			By("reporting the backup as completed once the job completes")
+			job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+			Expect(c.Status().Update(ctx, job)).To(Succeed())
+
+			_, backup = reconcileBackup(c, resourceName)
+			Expect(backup.Status.Ready).To(BeTrue())
+			Expect(backup.Status.Message).To(Equal(BackupCompletedSuccess))
+		})
+
+		It("should report a failed job", func() {
+			c := newFakeClient(schemaRegistry, newVolumeBackup(resourceName))
+
+			_, backup := reconcileBackup(c, resourceName)
+
+			job := &batchv1.Job{}
+			Expect(c.Get(ctx, types.NamespacedName{Name: backup.Status.LatestArchive, Namespace: "default"}, job)).To(Succeed())
+			job.Status.Conditions = []batchv1.JobCondition{{
+				Type:    batchv1.JobFailed,
+				Status:  corev1.ConditionTrue,
+				Message: "BackoffLimitExceeded",
+			}}
+			Expect(c.Status().Update(ctx, job)).To(Succeed())
+
+			_, backup = reconcileBackup(c, resourceName)
+			Expect(backup.Status.Ready).To(BeFalse())
+			Expect(backup.Status.Message).To(Equal("Failed to write archive " + backup.Status.LatestArchive + ": BackoffLimitExceeded"))
+		})
+
+		It("should give the job a valid name for backups with long names", func() {
+			name := strings.Repeat("long-backup-name-", 4) + "prod"
+			c := newFakeClient(schemaRegistry, newVolumeBackup(name))
+
+			_, backup := reconcileBackup(c, name)
+
+			jobs := &batchv1.JobList{}
+			Expect(c.List(ctx, jobs)).To(Succeed())
+			Expect(jobs.Items).To(HaveLen(1))
+			Expect(jobs.Items[0].Name).To(Equal(clientv1alpha1.LabelValue(backup.Status.LatestArchive)))
+			Expect(validation.IsDNS1123Label(jobs.Items[0].Name)).To(BeEmpty())
+		})
+	})
+})
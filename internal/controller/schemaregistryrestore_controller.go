@@ -0,0 +1,450 @@
+/*
+MIT License
+
+Copyright (c) 2025 Steffen Karlsson
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	clientv1alpha1 "github.com/steffen-karlsson/schema-registry-operator/api/v1alpha1"
+	"github.com/steffen-karlsson/schema-registry-operator/pkg/archive"
+	k8s_manager "github.com/steffen-karlsson/schema-registry-operator/pkg/k8s"
+)
+
+const (
+	RestoreCompletedSuccess = "Restore completed successfully"
+	RestoreJobContainerName = "archive-reader"
+)
+
+// SchemaRegistryRestoreReconciler reconciles a SchemaRegistryRestore object
+type SchemaRegistryRestoreReconciler struct {
+	k8s_manager.Client
+	Scheme *runtime.Scheme
+	// APIReader reads the archive ConfigMaps directly from the API server, as they are not cached
+	APIReader client.Reader
+	// PodLogs reads an archive stored in a PersistentVolumeClaim from the logs of the job reading it
+	PodLogs k8s_manager.PodLogReader
+}
+
+// +kubebuilder:rbac:groups=client.sroperator.io,resources=schemaregistryrestores,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=client.sroperator.io,resources=schemaregistryrestores/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=client.sroperator.io,resources=schemaregistryrestores/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=client.sroperator.io,resources=schemaregistrybackups,verbs=get;list;watch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups="",resources=pods,verbs=list
+// +kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+// the SchemaRegistryRestore object against the actual cluster state, and then
+// perform operations to make the cluster state reflect the state specified by
+// the user.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.19.0/pkg/reconcile
+func (r *SchemaRegistryRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	logger.Info("Reconciling SchemaRegistryRestore: ", "Name", req.Name, "Namespace", req.Namespace)
+
+	restore := &clientv1alpha1.SchemaRegistryRestore{}
+	err := r.Get(ctx, req.NamespacedName, restore)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("schema registry restore resource not found. Ignoring since object must be deleted")
+			return ctrl.Result{}, nil
+		}
+
+		logger.Error(err, "failed to get schema registry restore")
+		return ctrl.Result{}, err
+	}
+
+	// A restore is only run once, so there is nothing left to do when it has completed
+	if restore.Status.CompletionTime != nil {
+		return ctrl.Result{}, nil
+	}
+
+	// The purpose is to get the SchemaRegistry instance
+	schemaRegistry := &clientv1alpha1.SchemaRegistry{}
+	err = schemaRegistry.NewInstance(ctx, r, restore.ObjectMeta, restore)
+	switch {
+	case errors.Is(err, clientv1alpha1.ErrInstanceLabelNotFound) || errors.Is(err, clientv1alpha1.ErrInstanceNotFound):
+		logger.Info("schema registry instance not found")
+
+		if err = r.Status().Update(ctx, restore); err != nil {
+			logger.Error(err, "failed to update schema registry restore status")
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	case err != nil:
+		logger.Error(err, "failed to get schema registry instance")
+		return ctrl.Result{}, err
+	}
+
+	// The purpose is to leave the schema registry untouched while its reconciliation is paused
+	if clientv1alpha1.IsPaused(schemaRegistry.ObjectMeta) {
+		restore.UpdateStatus(false, "Schema Registry "+schemaRegistry.Name+" is paused")
+		if err = r.Status().Update(ctx, restore); err != nil {
+			logger.Error(err, "failed to update schema registry restore status")
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+
+	if !schemaRegistry.Status.Ready {
+		restore.UpdateStatus(false, "Schema Registry "+schemaRegistry.Name+" is not ready")
+		if err = r.Status().Update(ctx, restore); err != nil {
+			logger.Error(err, "failed to update schema registry restore status")
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+
+	archived, checksum, err := restore.LoadArchive(ctx, r.APIReader)
+	if errors.Is(err, clientv1alpha1.ErrArchiveNotFound) {
+		// The purpose is to stage an archive stored in a PersistentVolumeClaim in ConfigMaps, so it is loaded
+		// like an archive stored in ConfigMaps
+		backup, backupErr := restore.VolumeBackup(ctx, r.APIReader)
+		if backupErr != nil {
+			err = backupErr
+		} else if backup != nil {
+			return r.StageReconciler(ctx, restore, backup, logger)
+		}
+	}
+
+	if err != nil {
+		logger.Error(err, "failed to load archive", "archive", restore.Spec.Archive)
+		restore.UpdateStatus(false, "Failed to load archive: "+err.Error())
+
+		if updateErr := r.Status().Update(ctx, restore); updateErr != nil {
+			logger.Error(updateErr, "failed to update schema registry restore status")
+			return ctrl.Result{}, updateErr
+		}
+
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+
+	// The purpose is to avoid resuming a restore from a different archive than the one it was started from
+	if restore.Status.Checksum != "" && restore.Status.Checksum != checksum {
+		restore.UpdateStatus(false, "Archive "+restore.Spec.Archive+" has changed since the restore was started")
+		return ctrl.Result{}, r.Status().Update(ctx, restore)
+	}
+
+	return r.RestoreReconciler(ctx, restore, schemaRegistry, archived, checksum, logger)
+}
+
+// StageReconciler runs a job which reads the archive from the PersistentVolumeClaim of the backup, and stages the
+// archive in ConfigMaps once the job has completed. The job is deleted when it has finished, so a failed read is
+// retried with a new job.
+func (r *SchemaRegistryRestoreReconciler) StageReconciler(
+	ctx context.Context,
+	restore *clientv1alpha1.SchemaRegistryRestore,
+	backup *clientv1alpha1.SchemaRegistryBackup,
+	logger logr.Logger,
+) (ctrl.Result, error) {
+	job := &batchv1.Job{}
+	err := r.Get(ctx, types.NamespacedName{Name: getRestoreJobName(restore), Namespace: restore.Namespace}, job)
+	switch {
+	case apierrors.IsNotFound(err):
+		job = createRestoreJob(restore, backup)
+		if err = ctrl.SetControllerReference(restore, job, r.Scheme); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if err = r.Create(ctx, job); err != nil {
+			logger.Error(err, "failed to create archive job", "Job", job.Name)
+			return ctrl.Result{}, err
+		}
+
+		restore.UpdateStatus(false, "Reading archive "+restore.Spec.Archive+" from PersistentVolumeClaim "+
+			backup.Spec.Storage.ClaimName)
+		if err = r.Status().Update(ctx, restore); err != nil {
+			logger.Error(err, "failed to update schema registry restore status")
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{}, nil
+	case err != nil:
+		logger.Error(err, "failed to get archive job")
+		return ctrl.Result{}, err
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		switch condition.Type {
+		case batchv1.JobComplete:
+			err = r.stageArchive(ctx, restore, backup, job)
+		case batchv1.JobFailed:
+			err = fmt.Errorf("%w: %s", clientv1alpha1.ErrFailedToReadArchive, condition.Message)
+		default:
+			continue
+		}
+
+		deleteErr := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if client.IgnoreNotFound(deleteErr) != nil {
+			logger.Error(deleteErr, "failed to delete archive job", "Job", job.Name)
+		}
+
+		if err != nil {
+			logger.Error(err, "failed to stage archive", "archive", restore.Spec.Archive)
+			restore.UpdateStatus(false, "Failed to load archive: "+err.Error())
+
+			if err = r.Status().Update(ctx, restore); err != nil {
+				logger.Error(err, "failed to update schema registry restore status")
+				return ctrl.Result{}, err
+			}
+
+			return ctrl.Result{RequeueAfter: time.Minute}, nil
+		}
+
+		// The archive is staged, so it is loaded from its ConfigMaps on the next reconciliation
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	// The job is still running, the restore is reconciled again when the job has finished
+	return ctrl.Result{}, nil
+}
+
+// stageArchive reads the archive from the logs of the pod of the completed job, and stores it in ConfigMaps owned
+// by the restore, so they are garbage collected together with the restore
+func (r *SchemaRegistryRestoreReconciler) stageArchive(
+	ctx context.Context,
+	restore *clientv1alpha1.SchemaRegistryRestore,
+	backup *clientv1alpha1.SchemaRegistryBackup,
+	job *batchv1.Job,
+) error {
+	pods := &corev1.PodList{}
+	if err := r.APIReader.List(ctx, pods,
+		client.InNamespace(job.Namespace),
+		client.MatchingLabels{batchv1.JobNameLabel: job.Name}); err != nil {
+		return err
+	}
+
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodSucceeded {
+			continue
+		}
+
+		logs, err := r.PodLogs.GetLogs(ctx, pod.Namespace, pod.Name, RestoreJobContainerName)
+		if err != nil {
+			return fmt.Errorf("%w: %w", clientv1alpha1.ErrFailedToReadArchive, err)
+		}
+
+		data, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(string(logs)), ""))
+		if err != nil {
+			return fmt.Errorf("%w: %w", clientv1alpha1.ErrFailedToReadArchive, err)
+		}
+
+		// Only the checksum of the latest archive is known, the older archives are verified when they are decoded
+		checksum := archive.Checksum(data)
+		if backup.Status.LatestArchive == restore.Spec.Archive && backup.Status.Checksum != checksum {
+			return fmt.Errorf("%w: expected %s, got %s",
+				clientv1alpha1.ErrArchiveChecksumMismatch, backup.Status.Checksum, checksum)
+		}
+
+		labels := map[string]string{clientv1alpha1.ArchiveLabelName: clientv1alpha1.LabelValue(restore.Spec.Archive)}
+		return writeArchiveToConfigMaps(ctx, r.Client, r.Scheme, restore, job.Name, labels, checksum, data)
+	}
+
+	return fmt.Errorf("%w: job %s has no succeeded pod", clientv1alpha1.ErrFailedToReadArchive, job.Name)
+}
+
+// createRestoreJob returns a job which prints the archive in the PersistentVolumeClaim of the backup base64 encoded,
+// as the operator can only read the archive through the logs of the job
+func createRestoreJob(
+	restore *clientv1alpha1.SchemaRegistryRestore,
+	backup *clientv1alpha1.SchemaRegistryBackup,
+) *batchv1.Job {
+	source := BackupJobMountPath + "/" + backup.Name + "/" + restore.Spec.Archive + BackupArchiveFileSuffix
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getRestoreJobName(restore),
+			Namespace: restore.Namespace,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            ptr.To(int32(3)),
+			TTLSecondsAfterFinished: ptr.To(int32(BackupJobTTLSeconds)),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{
+						{
+							Name:    RestoreJobContainerName,
+							Image:   BackupJobImage,
+							Command: []string{"base64", source},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "backup",
+									MountPath: BackupJobMountPath,
+									ReadOnly:  true,
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "backup",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: backup.Spec.Storage.ClaimName,
+									ReadOnly:  true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// getRestoreJobName returns the name of the job reading the archive of the restore, which also names the ConfigMaps
+// the archive is staged in
+func getRestoreJobName(restore *clientv1alpha1.SchemaRegistryRestore) string {
+	return clientv1alpha1.LabelValue(restore.Name + "-archive")
+}
+
+// RestoreReconciler imports each subject of the archive which is not restored yet, and records the progress
+// in the status after each subject, so the restore can be resumed if the operator restarts
+func (r *SchemaRegistryRestoreReconciler) RestoreReconciler(
+	ctx context.Context,
+	restore *clientv1alpha1.SchemaRegistryRestore,
+	schemaRegistry *clientv1alpha1.SchemaRegistry,
+	archived *archive.Archive,
+	checksum string,
+	logger logr.Logger,
+) (ctrl.Result, error) {
+	logger.Info("Restoring Schema Registry: ", "Name", schemaRegistry.Name, "Archive", restore.Spec.Archive)
+
+	subjects := make(map[string]*archive.Subject, len(archived.Subjects))
+	for i := range archived.Subjects {
+		subjects[archived.Subjects[i].Name] = &archived.Subjects[i]
+	}
+
+	if restore.Status.Checksum == "" {
+		restore.Status.Checksum = checksum
+		restore.Status.Subjects = nil
+		// Subjects are restored after the subjects they reference, as a schema can only be imported once its
+		// references exist
+		for _, subject := range archive.OrderByReferences(archived.Subjects) {
+			if subject.Deleted && !restore.Spec.IncludeDeleted {
+				continue
+			}
+
+			restore.Status.Subjects = append(restore.Status.Subjects, clientv1alpha1.SubjectRestoreStatus{
+				Name:     subject.Name,
+				Versions: len(subject.Versions),
+			})
+		}
+
+		restore.Status.SubjectCount = len(restore.Status.Subjects)
+		restore.UpdateStatus(false, "Restoring archive "+restore.Spec.Archive)
+		if err := r.Status().Update(ctx, restore); err != nil {
+			logger.Error(err, "failed to update schema registry restore status")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// A failed subject does not stop the restore, the failed subjects are retried on the next reconciliation
+	var failed []string
+	for i := range restore.Status.Subjects {
+		subjectStatus := &restore.Status.Subjects[i]
+		if subjectStatus.Restored {
+			continue
+		}
+
+		restored, err := schemaRegistry.RestoreSubject(ctx, subjects[subjectStatus.Name], restore.Spec.IncludeDeleted, logger)
+		subjectStatus.RestoredVersions = restored
+		if err != nil {
+			logger.Error(err, "failed to restore subject", "subject", subjectStatus.Name)
+			subjectStatus.Message = err.Error()
+			failed = append(failed, subjectStatus.Name)
+			continue
+		}
+
+		subjectStatus.Restored = true
+		subjectStatus.Message = ""
+		restore.Status.RestoredSubjectCount++
+		restore.UpdateStatus(false, fmt.Sprintf("Restored %d of %d subjects",
+			restore.Status.RestoredSubjectCount, restore.Status.SubjectCount))
+
+		if err = r.Status().Update(ctx, restore); err != nil {
+			logger.Error(err, "failed to update schema registry restore status")
+			return ctrl.Result{}, err
+		}
+	}
+
+	if len(failed) > 0 {
+		restore.UpdateStatus(false, fmt.Sprintf("Failed to restore %d of %d subjects: %s",
+			len(failed), restore.Status.SubjectCount, strings.Join(failed, ", ")))
+
+		if err := r.Status().Update(ctx, restore); err != nil {
+			logger.Error(err, "failed to update schema registry restore status")
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+
+	restore.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+	restore.UpdateStatus(true, RestoreCompletedSuccess)
+	if err := r.Status().Update(ctx, restore); err != nil {
+		logger.Error(err, "failed to update schema registry restore status")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SchemaRegistryRestoreReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&clientv1alpha1.SchemaRegistryRestore{}).
+		Owns(&batchv1.Job{}).
+		Complete(r)
+}
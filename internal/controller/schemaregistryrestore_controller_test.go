@@ -0,0 +1,427 @@
+/*
+MIT License
+
+Copyright (c) 2025 Steffen Karlsson
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	clientv1alpha1 "github.com/steffen-karlsson/schema-registry-operator/api/v1alpha1"
+	"github.com/steffen-karlsson/schema-registry-operator/pkg/archive"
+	k8s_manager "github.com/steffen-karlsson/schema-registry-operator/pkg/k8s"
+	"github.com/steffen-karlsson/schema-registry-operator/pkg/srclient"
+)
+
+var _ = Describe("SchemaRegistryRestore Controller", func() {
+	const (
+		resourceName = "test-restore"
+		archiveName  = "test-backup-20250102-030405"
+	)
+
+	var (
+		ctx            context.Context
+		registries     *fakeRegistries
+		schemaRegistry *clientv1alpha1.SchemaRegistry
+		podLogs        fakePodLogReader
+	)
+
+	typeNamespacedName := types.NamespacedName{
+		Name:      resourceName,
+		Namespace: "default",
+	}
+
+	reference := func(subject string, version int32) []srclient.SchemaReference {
+		return []srclient.SchemaReference{{Name: ptr.To(subject), Subject: ptr.To(subject), Version: ptr.To(version)}}
+	}
+
+	// newArchive returns an archive where the order subject, which is first alphabetically, references the customer
+	newArchive := func() *archive.Archive {
+		return &archive.Archive{
+			FormatVersion: archive.FormatVersion,
+			CreatedAt:     time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+			Registry:      "source",
+			Subjects: []archive.Subject{
+				{
+					Name: "a-order",
+					Versions: []archive.Version{
+						{Version: 1, ID: 10, Schema: `{"type":"string"}`},
+						{Version: 2, ID: 12, Schema: `{"type":"record","name":"Order"}`, References: reference("z-customer", 1)},
+					},
+				},
+				{
+					Name: "m-deleted",
+					Versions: []archive.Version{
+						{Version: 1, ID: 13, Schema: `{"type":"int"}`, Deleted: true},
+					},
+					Deleted: true,
+				},
+				{
+					Name: "z-customer",
+					Mode: "READONLY",
+					Versions: []archive.Version{
+						{Version: 1, ID: 11, Schema: `{"type":"record","name":"Customer"}`},
+					},
+				},
+			},
+		}
+	}
+
+	// newArchiveConfigMaps stores the archive in ConfigMaps as a SchemaRegistryBackup does
+	newArchiveConfigMaps := func(a *archive.Archive, chunkSize int) []client.Object {
+		data, err := archive.Encode(a)
+		Expect(err).NotTo(HaveOccurred())
+
+		chunks := archive.Split(data, chunkSize)
+		configMaps := make([]client.Object, 0, len(chunks))
+		for i, chunk := range chunks {
+			configMaps = append(configMaps, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      archiveName + "-" + strconv.Itoa(i),
+					Namespace: "default",
+					Labels:    map[string]string{clientv1alpha1.ArchiveLabelName: archiveName},
+					Annotations: map[string]string{
+						clientv1alpha1.ArchiveChunkAnnotation:    strconv.Itoa(i) + "/" + strconv.Itoa(len(chunks)),
+						clientv1alpha1.ArchiveChecksumAnnotation: archive.Checksum(data),
+					},
+				},
+				BinaryData: map[string][]byte{clientv1alpha1.ArchiveDataKey: chunk},
+			})
+		}
+
+		return configMaps
+	}
+
+	newRestore := func(includeDeleted bool) *clientv1alpha1.SchemaRegistryRestore {
+		return &clientv1alpha1.SchemaRegistryRestore{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      resourceName,
+				Namespace: "default",
+				Labels:    instanceLabels(schemaRegistry.Name),
+			},
+			Spec: clientv1alpha1.SchemaRegistryRestoreSpec{
+				Archive:        archiveName,
+				IncludeDeleted: includeDeleted,
+			},
+		}
+	}
+
+	reconcileRestore := func(c *k8s_manager.Client) (reconcile.Result, *clientv1alpha1.SchemaRegistryRestore) {
+		controllerReconciler := &SchemaRegistryRestoreReconciler{
+			Client:    *c,
+			Scheme:    c.Scheme(),
+			APIReader: c,
+			PodLogs:   podLogs,
+		}
+
+		result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		restore := &clientv1alpha1.SchemaRegistryRestore{}
+		Expect(c.Get(ctx, typeNamespacedName, restore)).To(Succeed())
+		return result, restore
+	}
+
+	subjectNames := func(restore *clientv1alpha1.SchemaRegistryRestore) []string {
+		var names []string
+		for _, subject := range restore.Status.Subjects {
+			names = append(names, subject.Name)
+		}
+		return names
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		registries = newFakeRegistries()
+		schemaRegistry = newReadySchemaRegistry("restore-registry")
+		podLogs = fakePodLogReader{}
+	})
+
+	AfterEach(func() {
+		registries.Close()
+	})
+
+	Context("When restoring an archive stored in ConfigMaps", func() {
+		It("should import referenced subjects before the subjects referencing them", func() {
+			objects := append(newArchiveConfigMaps(newArchive(), 64), schemaRegistry, newRestore(false))
+			c := newFakeClient(objects...)
+
+			result, restore := reconcileRestore(c)
+			Expect(result).To(Equal(reconcile.Result{}))
+			Expect(restore.Status.Ready).To(BeTrue())
+			Expect(restore.Status.Message).To(Equal(RestoreCompletedSuccess))
+			Expect(restore.Status.CompletionTime).NotTo(BeNil())
+			Expect(restore.Status.SubjectCount).To(Equal(2))
+			Expect(restore.Status.RestoredSubjectCount).To(Equal(2))
+			Expect(subjectNames(restore)).To(Equal([]string{"z-customer", "a-order"}))
+
+			registry := registries.registry(schemaRegistry)
+			Expect(registry.activeVersions("a-order")).To(Equal([]int32{1, 2}))
+			Expect(registry.version("a-order", 2).ID).To(Equal(int32(12)))
+			Expect(registry.version("a-order", 2).References).To(Equal(reference("z-customer", 1)))
+			Expect(registry.version("z-customer", 1).ID).To(Equal(int32(11)))
+			Expect(registry.activeVersions("m-deleted")).To(BeEmpty())
+
+			By("resetting the mode of the subjects after the import")
+			Expect(registry.subjectMode("a-order")).To(BeEmpty())
+			Expect(registry.subjectMode("z-customer")).To(Equal("READONLY"))
+
+			By("not restoring again once completed")
+			result, _ = reconcileRestore(c)
+			Expect(result).To(Equal(reconcile.Result{}))
+		})
+
+		It("should restore soft deleted subjects when deleted subjects are included", func() {
+			objects := append(newArchiveConfigMaps(newArchive(), 1024), schemaRegistry, newRestore(true))
+			c := newFakeClient(objects...)
+
+			_, restore := reconcileRestore(c)
+			Expect(restore.Status.Ready).To(BeTrue())
+			Expect(restore.Status.SubjectCount).To(Equal(3))
+
+			registry := registries.registry(schemaRegistry)
+			Expect(registry.activeVersions("m-deleted")).To(BeEmpty())
+			Expect(registry.version("m-deleted", 1)).NotTo(BeNil())
+			Expect(registry.version("m-deleted", 1).Deleted).To(BeTrue())
+		})
+
+		It("should continue past failed subjects and retry only those", func() {
+			archived := newArchive()
+			archived.Subjects[0].Versions[1].References = reference("external", 1)
+			objects := append(newArchiveConfigMaps(archived, 1024), schemaRegistry, newRestore(false))
+			c := newFakeClient(objects...)
+
+			result, restore := reconcileRestore(c)
+			Expect(result.RequeueAfter).To(Equal(time.Minute))
+			Expect(restore.Status.Ready).To(BeFalse())
+			Expect(restore.Status.Message).To(Equal("Failed to restore 1 of 2 subjects: a-order"))
+			Expect(restore.Status.RestoredSubjectCount).To(Equal(1))
+			Expect(restore.Status.CompletionTime).To(BeNil())
+
+			Expect(subjectNames(restore)).To(Equal([]string{"a-order", "z-customer"}))
+			failed := restore.Status.Subjects[0]
+			Expect(failed.Restored).To(BeFalse())
+			Expect(failed.RestoredVersions).To(Equal(1))
+			Expect(failed.Message).NotTo(BeEmpty())
+
+			registry := registries.registry(schemaRegistry)
+			Expect(registry.version("z-customer", 1)).NotTo(BeNil())
+
+			By("resetting the mode of the failed subject")
+			Expect(registry.subjectMode("a-order")).To(BeEmpty())
+
+			By("completing the restore once the reference exists")
+			registry.register("external", `{"type":"string"}`)
+
+			result, restore = reconcileRestore(c)
+			Expect(result).To(Equal(reconcile.Result{}))
+			Expect(restore.Status.Ready).To(BeTrue())
+			Expect(restore.Status.RestoredSubjectCount).To(Equal(2))
+			Expect(registry.activeVersions("a-order")).To(Equal([]int32{1, 2}))
+		})
+
+		It("should refuse to resume from a changed archive", func() {
+			restore := newRestore(false)
+			restore.Status.Checksum = "sha256:other"
+			objects := append(newArchiveConfigMaps(newArchive(), 1024), schemaRegistry, restore)
+			c := newFakeClient(objects...)
+
+			_, restore = reconcileRestore(c)
+			Expect(restore.Status.Ready).To(BeFalse())
+			Expect(restore.Status.Message).To(Equal("Archive " + archiveName + " has changed since the restore was started"))
+			Expect(registries.registry(schemaRegistry).activeVersions("a-order")).To(BeEmpty())
+		})
+	})
+
+	Context("When the archive cannot be loaded", func() {
+		It("should retry when the archive is not found", func() {
+			c := newFakeClient(schemaRegistry, newRestore(false))
+
+			result, restore := reconcileRestore(c)
+			Expect(result.RequeueAfter).To(Equal(time.Minute))
+			Expect(restore.Status.Ready).To(BeFalse())
+			Expect(restore.Status.Message).To(ContainSubstring(clientv1alpha1.ErrArchiveNotFound.Error()))
+		})
+	})
+
+	Context("When restoring an archive stored in a PersistentVolumeClaim", func() {
+		var data []byte
+
+		jobName := types.NamespacedName{Name: resourceName + "-archive", Namespace: "default"}
+
+		newVolumeBackup := func(checksum string) *clientv1alpha1.SchemaRegistryBackup {
+			return &clientv1alpha1.SchemaRegistryBackup{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-backup", Namespace: "default"},
+				Spec: clientv1alpha1.SchemaRegistryBackupSpec{
+					Storage: clientv1alpha1.BackupStorage{
+						Type:      clientv1alpha1.BackupStoragePersistentVolumeClaim,
+						ClaimName: "backups",
+					},
+				},
+				Status: clientv1alpha1.SchemaRegistryBackupStatus{
+					LatestArchive: archiveName,
+					Checksum:      checksum,
+				},
+			}
+		}
+
+		// finishJob marks the archive job as finished, with a pod which printed the given logs when it completed
+		finishJob := func(c *k8s_manager.Client, conditionType batchv1.JobConditionType, logs string) {
+			job := &batchv1.Job{}
+			Expect(c.Get(ctx, jobName, job)).To(Succeed())
+			job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{
+				Type:    conditionType,
+				Status:  corev1.ConditionTrue,
+				Message: "BackoffLimitExceeded",
+			})
+			Expect(c.Status().Update(ctx, job)).To(Succeed())
+
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      jobName.Name + "-pod",
+					Namespace: "default",
+					Labels:    map[string]string{batchv1.JobNameLabel: jobName.Name},
+				},
+				Status: corev1.PodStatus{Phase: corev1.PodSucceeded},
+			}
+			Expect(c.Create(ctx, pod)).To(Succeed())
+			podLogs[pod.Name] = []byte(logs)
+		}
+
+		// encodeLogs encodes the archive as the job prints it, wrapped in lines of 76 characters
+		encodeLogs := func(data []byte) string {
+			encoded := base64.StdEncoding.EncodeToString(data)
+			var lines []string
+			for len(encoded) > 76 {
+				lines = append(lines, encoded[:76])
+				encoded = encoded[76:]
+			}
+			return strings.Join(append(lines, encoded), "\n") + "\n"
+		}
+
+		BeforeEach(func() {
+			var err error
+			data, err = archive.Encode(newArchive())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should read the archive with a job and restore it once staged", func() {
+			c := newFakeClient(schemaRegistry, newVolumeBackup(archive.Checksum(data)), newRestore(false))
+
+			result, restore := reconcileRestore(c)
+			Expect(result).To(Equal(reconcile.Result{}))
+			Expect(restore.Status.Ready).To(BeFalse())
+			Expect(restore.Status.Message).To(Equal("Reading archive " + archiveName + " from PersistentVolumeClaim backups"))
+
+			job := &batchv1.Job{}
+			Expect(c.Get(ctx, jobName, job)).To(Succeed())
+			Expect(job.OwnerReferences).To(HaveLen(1))
+			Expect(job.OwnerReferences[0].Name).To(Equal(resourceName))
+			podSpec := job.Spec.Template.Spec
+			Expect(podSpec.Containers[0].Command).To(Equal([]string{"base64",
+				BackupJobMountPath + "/test-backup/" + archiveName + BackupArchiveFileSuffix}))
+			Expect(podSpec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("backups"))
+			Expect(podSpec.Volumes[0].PersistentVolumeClaim.ReadOnly).To(BeTrue())
+
+			By("waiting while the job is running")
+			result, _ = reconcileRestore(c)
+			Expect(result).To(Equal(reconcile.Result{}))
+			Expect(registries.registry(schemaRegistry).activeVersions("a-order")).To(BeEmpty())
+
+			By("staging the archive from the logs of the completed job")
+			finishJob(c, batchv1.JobComplete, encodeLogs(data))
+			result, _ = reconcileRestore(c)
+			Expect(result.Requeue).To(BeTrue())
+			Expect(apierrors.IsNotFound(c.Get(ctx, jobName, &batchv1.Job{}))).To(BeTrue())
+
+			configMaps := &corev1.ConfigMapList{}
+			Expect(c.List(ctx, configMaps, client.MatchingLabels{clientv1alpha1.ArchiveLabelName: archiveName})).To(Succeed())
+			Expect(configMaps.Items).NotTo(BeEmpty())
+			for _, configMap := range configMaps.Items {
+				Expect(configMap.OwnerReferences[0].Name).To(Equal(resourceName))
+			}
+
+			By("restoring the staged archive")
+			result, restore = reconcileRestore(c)
+			Expect(result).To(Equal(reconcile.Result{}))
+			Expect(restore.Status.Ready).To(BeTrue())
+			Expect(restore.Status.Checksum).To(Equal(archive.Checksum(data)))
+			Expect(registries.registry(schemaRegistry).activeVersions("a-order")).To(Equal([]int32{1, 2}))
+		})
+
+		It("should retry with a new job when the job fails", func() {
+			c := newFakeClient(schemaRegistry, newVolumeBackup(archive.Checksum(data)), newRestore(false))
+			reconcileRestore(c)
+
+			finishJob(c, batchv1.JobFailed, "")
+			result, restore := reconcileRestore(c)
+			Expect(result.RequeueAfter).To(Equal(time.Minute))
+			Expect(restore.Status.Ready).To(BeFalse())
+			Expect(restore.Status.Message).To(ContainSubstring(clientv1alpha1.ErrFailedToReadArchive.Error()))
+			Expect(apierrors.IsNotFound(c.Get(ctx, jobName, &batchv1.Job{}))).To(BeTrue())
+
+			By("starting a new job on the next reconciliation")
+			reconcileRestore(c)
+			Expect(c.Get(ctx, jobName, &batchv1.Job{})).To(Succeed())
+		})
+
+		It("should not stage an archive whose checksum differs from the backup", func() {
+			c := newFakeClient(schemaRegistry, newVolumeBackup("sha256:other"), newRestore(false))
+			reconcileRestore(c)
+
+			finishJob(c, batchv1.JobComplete, encodeLogs(data))
+			result, restore := reconcileRestore(c)
+			Expect(result.RequeueAfter).To(Equal(time.Minute))
+			Expect(restore.Status.Message).To(ContainSubstring(clientv1alpha1.ErrArchiveChecksumMismatch.Error()))
+			Expect(archiveConfigMaps(ctx, c)).To(BeEmpty())
+		})
+	})
+})
+
+// fakePodLogReader returns the logs of the pods by their name
+type fakePodLogReader map[string][]byte
+
+func (r fakePodLogReader) GetLogs(_ context.Context, _, name, _ string) ([]byte, error) {
+	logs, ok := r[name]
+	if !ok {
+		return nil, errors.New("pod " + name + " not found")
+	}
+
+	return logs, nil
+}
@@ -0,0 +1,229 @@
+/*
+MIT License
+
+Copyright (c) 2025 Steffen Karlsson
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	clientv1alpha1 "github.com/steffen-karlsson/schema-registry-operator/api/v1alpha1"
+	k8s_manager "github.com/steffen-karlsson/schema-registry-operator/pkg/k8s"
+)
+
+const (
+	RollbackCompletedSuccess = "Rollback completed successfully"
+)
+
+// SchemaRollbackReconciler reconciles a SchemaRollback object
+type SchemaRollbackReconciler struct {
+	k8s_manager.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=client.sroperator.io,resources=schemarollbacks,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=client.sroperator.io,resources=schemarollbacks/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=client.sroperator.io,resources=schemarollbacks/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+// the SchemaRollback object against the actual cluster state, and then
+// perform operations to make the cluster state reflect the state specified by
+// the user.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.19.0/pkg/reconcile
+func (r *SchemaRollbackReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	logger.Info("Reconciling SchemaRollback: ", "Name", req.Name, "Namespace", req.Namespace)
+
+	rollback := &clientv1alpha1.SchemaRollback{}
+	err := r.Get(ctx, req.NamespacedName, rollback)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("schema rollback resource not found. Ignoring since object must be deleted")
+			return ctrl.Result{}, nil
+		}
+
+		logger.Error(err, "failed to get schema rollback")
+		return ctrl.Result{}, err
+	}
+
+	// A rollback is only run once, so there is nothing left to do when it has completed
+	if rollback.Status.CompletionTime != nil {
+		return ctrl.Result{}, nil
+	}
+
+	schema := &clientv1alpha1.Schema{}
+	err = r.Get(ctx, types.NamespacedName{Name: rollback.Spec.Schema, Namespace: rollback.Namespace}, schema)
+	switch {
+	case apierrors.IsNotFound(err):
+		rollback.UpdateStatus(false, "Schema "+rollback.Spec.Schema+" not found")
+		if err = r.Status().Update(ctx, rollback); err != nil {
+			logger.Error(err, "failed to update schema rollback status")
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	case err != nil:
+		logger.Error(err, "failed to get schema")
+		return ctrl.Result{}, err
+	}
+
+	if clientv1alpha1.IsPaused(schema.ObjectMeta) || schema.Spec.DryRun {
+		rollback.UpdateStatus(false, "Schema "+schema.Name+" is paused or in dry run mode")
+		if err = r.Status().Update(ctx, rollback); err != nil {
+			logger.Error(err, "failed to update schema rollback status")
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+
+	// The purpose is to get the first SchemaRegistry instance of the schema, the rolled back content is
+	// deployed to the other instances by the next reconciliation of the Schema
+	targets, _, err := schema.ResolveTargets(ctx, r)
+	if err != nil {
+		logger.Error(err, "failed to resolve schema registry instances")
+		return ctrl.Result{}, err
+	}
+
+	if len(targets) == 0 {
+		logger.Info("schema registry instance not found")
+		rollback.UpdateStatus(false, "Schema Registry instance not found")
+
+		if err = r.Status().Update(ctx, rollback); err != nil {
+			logger.Error(err, "failed to update schema rollback status")
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+
+	schemaRegistry := &targets[0]
+
+	// The purpose is to leave the schema registry untouched while its reconciliation is paused
+	if clientv1alpha1.IsPaused(schemaRegistry.ObjectMeta) {
+		rollback.UpdateStatus(false, "Schema Registry "+schemaRegistry.Name+" is paused")
+		if err = r.Status().Update(ctx, rollback); err != nil {
+			logger.Error(err, "failed to update schema rollback status")
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+
+	if !schemaRegistry.Status.Ready {
+		rollback.UpdateStatus(false, "Schema Registry "+schemaRegistry.Name+" is not ready")
+		if err = r.Status().Update(ctx, rollback); err != nil {
+			logger.Error(err, "failed to update schema rollback status")
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+
+	return r.RollbackReconciler(ctx, rollback, schema, schemaRegistry, logger)
+}
+
+// RollbackReconciler rolls the schema back in the schema registry, and updates the Schema to the content
+// of the rolled back version, so the next reconciliation of the Schema does not register the newer content again
+func (r *SchemaRollbackReconciler) RollbackReconciler(
+	ctx context.Context,
+	rollback *clientv1alpha1.SchemaRollback,
+	schema *clientv1alpha1.Schema,
+	schemaRegistry *clientv1alpha1.SchemaRegistry,
+	logger logr.Logger,
+) (ctrl.Result, error) {
+	logger.Info("Rolling back Schema: ", "Name", schema.Name, "Version", rollback.Spec.RollbackToVersion)
+
+	previousVersion := int32(schema.Status.LatestVersion)
+	latest, deleted, err := schemaRegistry.RollbackSchema(ctx, schema, rollback, logger)
+	rollback.Status.DeletedVersions = append(rollback.Status.DeletedVersions, deleted...)
+	if err != nil {
+		logger.Error(err, "failed to roll back schema")
+		rollback.UpdateStatus(false, "Failed to roll back schema: "+err.Error())
+
+		if err = r.Status().Update(ctx, rollback); err != nil {
+			logger.Error(err, "failed to update schema rollback status")
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+
+	schema.SetRegisteredSchema(latest)
+	if err = r.Update(ctx, schema); err != nil {
+		logger.Error(err, "failed to update schema")
+		return ctrl.Result{}, err
+	}
+
+	err = schema.RecordRegisteredSchema(schemaRegistry.Name, latest, &clientv1alpha1.SchemaRollbackRecord{
+		Name:            rollback.Name,
+		FromVersion:     previousVersion,
+		ToVersion:       rollback.Spec.RollbackToVersion,
+		Policy:          rollback.Spec.Policy,
+		DeletedVersions: rollback.Status.DeletedVersions,
+	})
+	if err != nil {
+		logger.Error(err, "failed to record schema history")
+		return ctrl.Result{}, err
+	}
+
+	schema.UpdateStatus(true, fmt.Sprintf("Rolled back to version %d by %s",
+		rollback.Spec.RollbackToVersion, rollback.Name))
+
+	if err = r.Status().Update(ctx, schema); err != nil {
+		logger.Error(err, "failed to update schema status")
+		return ctrl.Result{}, err
+	}
+
+	rollback.Status.PreviousVersion = previousVersion
+	rollback.Status.LatestVersion = ptr.Deref(latest.Version, 0)
+	rollback.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+	rollback.UpdateStatus(true, RollbackCompletedSuccess)
+	if err = r.Status().Update(ctx, rollback); err != nil {
+		logger.Error(err, "failed to update schema rollback status")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SchemaRollbackReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&clientv1alpha1.SchemaRollback{}).
+		Complete(r)
+}
@@ -0,0 +1,236 @@
+/*
+MIT License
+
+Copyright (c) 2025 Steffen Karlsson
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	clientv1alpha1 "github.com/steffen-karlsson/schema-registry-operator/api/v1alpha1"
+	k8s_manager "github.com/steffen-karlsson/schema-registry-operator/pkg/k8s"
+)
+
+var _ = Describe("SchemaRollback Controller", func() {
+	const (
+		resourceName = "test-rollback"
+		schemaName   = "test-schema"
+		subject      = "orders-value"
+	)
+
+	var (
+		ctx            context.Context
+		registries     *fakeRegistries
+		schemaRegistry *clientv1alpha1.SchemaRegistry
+		registry       *fakeRegistry
+	)
+
+	contents := []string{
+		`{"type":"record","name":"Order","fields":[{"name":"id","type":"string"}]}`,
+		`{"type":"record","name":"Order","fields":[{"name":"id","type":"string"},{"name":"a","type":"string","default":""}]}`,
+		`{"type":"record","name":"Order","fields":[{"name":"id","type":"string"},{"name":"b","type":"string","default":""}]}`,
+	}
+
+	newSchema := func() *clientv1alpha1.Schema {
+		return &clientv1alpha1.Schema{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      schemaName,
+				Namespace: "default",
+				Labels:    instanceLabels(schemaRegistry.Name),
+			},
+			Spec: clientv1alpha1.SchemaSpec{
+				Subject:            "orders",
+				Target:             "VALUE",
+				Type:               "AVRO",
+				Content:            contents[2],
+				CompatibilityLevel: "NONE",
+			},
+			Status: clientv1alpha1.SchemaStatus{
+				Ready:         true,
+				LatestVersion: 3,
+			},
+		}
+	}
+
+	newRollback := func(version int32, policy string) *clientv1alpha1.SchemaRollback {
+		return &clientv1alpha1.SchemaRollback{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      resourceName,
+				Namespace: "default",
+			},
+			Spec: clientv1alpha1.SchemaRollbackSpec{
+				Schema:            schemaName,
+				RollbackToVersion: version,
+				Policy:            policy,
+			},
+		}
+	}
+
+	reconcileRollback := func(c *k8s_manager.Client) (reconcile.Result, *clientv1alpha1.SchemaRollback, *clientv1alpha1.Schema) {
+		controllerReconciler := &SchemaRollbackReconciler{
+			Client: *c,
+			Scheme: c.Scheme(),
+		}
+
+		result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: resourceName, Namespace: "default"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		rollback := &clientv1alpha1.SchemaRollback{}
+		Expect(c.Get(ctx, types.NamespacedName{Name: resourceName, Namespace: "default"}, rollback)).To(Succeed())
+
+		schema := &clientv1alpha1.Schema{}
+		Expect(c.Get(ctx, types.NamespacedName{Name: schemaName, Namespace: "default"}, schema)).To(Succeed())
+
+		return result, rollback, schema
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		registries = newFakeRegistries()
+		schemaRegistry = newReadySchemaRegistry("test-sr")
+		registry = registries.registry(schemaRegistry)
+
+		for _, content := range contents {
+			registry.register(subject, content)
+		}
+	})
+
+	AfterEach(func() {
+		registries.Close()
+	})
+
+	It("should register the content of the version again with the Reregister policy", func() {
+		c := newFakeClient(schemaRegistry, newSchema(), newRollback(1, clientv1alpha1.RollbackPolicyReregister))
+
+		result, rollback, schema := reconcileRollback(c)
+		Expect(result.RequeueAfter).To(BeZero())
+
+		By("keeping the newer versions and registering version 1 as version 4")
+		Expect(registry.activeVersions(subject)).To(Equal([]int32{1, 2, 3, 4}))
+		Expect(registry.version(subject, 4).Schema).To(Equal(contents[0]))
+		Expect(registry.version(subject, 4).Metadata).NotTo(BeNil())
+		Expect(*registry.version(subject, 4).Metadata.Properties).To(HaveKeyWithValue(
+			clientv1alpha1.RollbackNameProperty, resourceName))
+		Expect(*registry.version(subject, 4).Metadata.Properties).To(HaveKeyWithValue(
+			clientv1alpha1.RollbackVersionProperty, "1"))
+
+		By("completing the rollback")
+		Expect(rollback.Status.Ready).To(BeTrue())
+		Expect(rollback.Status.Message).To(Equal(RollbackCompletedSuccess))
+		Expect(rollback.Status.CompletionTime).NotTo(BeNil())
+		Expect(rollback.Status.PreviousVersion).To(Equal(int32(3)))
+		Expect(rollback.Status.LatestVersion).To(Equal(int32(4)))
+		Expect(rollback.Status.DeletedVersions).To(BeEmpty())
+
+		By("updating the Schema to the rolled back content and recording the rollback in its history")
+		Expect(schema.Spec.Content).To(Equal(contents[0]))
+		Expect(schema.Status.LatestVersion).To(Equal(4))
+		Expect(schema.Status.History).To(HaveLen(1))
+		Expect(schema.Status.History[0].Version).To(Equal(int32(4)))
+		Expect(schema.Status.History[0].ID).To(Equal(registry.version(subject, 4).ID))
+		Expect(schema.Status.History[0].Rollback).To(Equal(&clientv1alpha1.SchemaRollbackRecord{
+			Name:        resourceName,
+			FromVersion: 3,
+			ToVersion:   1,
+			Policy:      clientv1alpha1.RollbackPolicyReregister,
+		}))
+	})
+
+	It("should permanently delete the newer versions with the DeleteNewer policy", func() {
+		c := newFakeClient(schemaRegistry, newSchema(), newRollback(1, clientv1alpha1.RollbackPolicyDeleteNewer))
+
+		result, rollback, schema := reconcileRollback(c)
+		Expect(result.RequeueAfter).To(BeZero())
+
+		By("deleting versions 2 and 3 permanently")
+		Expect(registry.activeVersions(subject)).To(Equal([]int32{1}))
+		Expect(registry.version(subject, 2)).To(BeNil())
+		Expect(registry.version(subject, 3)).To(BeNil())
+
+		By("completing the rollback")
+		Expect(rollback.Status.Ready).To(BeTrue())
+		Expect(rollback.Status.CompletionTime).NotTo(BeNil())
+		Expect(rollback.Status.PreviousVersion).To(Equal(int32(3)))
+		Expect(rollback.Status.LatestVersion).To(Equal(int32(1)))
+		Expect(rollback.Status.DeletedVersions).To(Equal([]int32{3, 2}))
+
+		By("updating the Schema to the rolled back content and recording the rollback in its history")
+		Expect(schema.Spec.Content).To(Equal(contents[0]))
+		Expect(schema.Status.LatestVersion).To(Equal(1))
+		Expect(schema.Status.History).To(HaveLen(1))
+		Expect(schema.Status.History[0].Version).To(Equal(int32(1)))
+		Expect(schema.Status.History[0].Rollback).To(Equal(&clientv1alpha1.SchemaRollbackRecord{
+			Name:            resourceName,
+			FromVersion:     3,
+			ToVersion:       1,
+			Policy:          clientv1alpha1.RollbackPolicyDeleteNewer,
+			DeletedVersions: []int32{3, 2},
+		}))
+	})
+
+	It("should not roll back again once the rollback has completed", func() {
+		c := newFakeClient(schemaRegistry, newSchema(), newRollback(1, clientv1alpha1.RollbackPolicyReregister))
+
+		reconcileRollback(c)
+		Expect(registry.activeVersions(subject)).To(Equal([]int32{1, 2, 3, 4}))
+
+		_, rollback, schema := reconcileRollback(c)
+		Expect(registry.activeVersions(subject)).To(Equal([]int32{1, 2, 3, 4}))
+		Expect(rollback.Status.LatestVersion).To(Equal(int32(4)))
+		Expect(schema.Status.History).To(HaveLen(1))
+	})
+
+	It("should fail the rollback to a version which does not exist", func() {
+		c := newFakeClient(schemaRegistry, newSchema(), newRollback(7, clientv1alpha1.RollbackPolicyDeleteNewer))
+
+		result, rollback, schema := reconcileRollback(c)
+		Expect(result.RequeueAfter).NotTo(BeZero())
+
+		Expect(registry.activeVersions(subject)).To(Equal([]int32{1, 2, 3}))
+		Expect(rollback.Status.Ready).To(BeFalse())
+		Expect(rollback.Status.Message).To(ContainSubstring(clientv1alpha1.ErrVersionNotFound.Error()))
+		Expect(rollback.Status.CompletionTime).To(BeNil())
+		Expect(schema.Spec.Content).To(Equal(contents[2]))
+		Expect(schema.Status.History).To(BeEmpty())
+	})
+
+	It("should wait for the Schema Registry to be ready", func() {
+		schemaRegistry.Status.Ready = false
+		c := newFakeClient(schemaRegistry, newSchema(), newRollback(1, clientv1alpha1.RollbackPolicyDeleteNewer))
+
+		result, rollback, _ := reconcileRollback(c)
+		Expect(result.RequeueAfter).NotTo(BeZero())
+
+		Expect(registry.activeVersions(subject)).To(Equal([]int32{1, 2, 3}))
+		Expect(rollback.Status.Ready).To(BeFalse())
+		Expect(rollback.Status.Message).To(Equal("Schema Registry test-sr is not ready"))
+	})
+})
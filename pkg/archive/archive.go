@@ -0,0 +1,156 @@
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/steffen-karlsson/schema-registry-operator/pkg/srclient"
+)
+
+// FormatVersion is the version of the archive format written by Encode
+const FormatVersion = 1
+
+// Archive is a point in time export of all subjects in a schema registry
+type Archive struct {
+	FormatVersion int              `json:"formatVersion"`
+	CreatedAt     time.Time        `json:"createdAt"`
+	Registry      string           `json:"registry"`
+	Config        *srclient.Config `json:"config,omitempty"`
+	Mode          string           `json:"mode,omitempty"`
+	Subjects      []Subject        `json:"subjects"`
+}
+
+// Subject is a subject with its configuration and all of its versions
+type Subject struct {
+	Name     string           `json:"name"`
+	Deleted  bool             `json:"deleted,omitempty"`
+	Config   *srclient.Config `json:"config,omitempty"`
+	Mode     string           `json:"mode,omitempty"`
+	Versions []Version        `json:"versions"`
+}
+
+// Version is a single registered version of a subject
+type Version struct {
+	Version    int32                      `json:"version"`
+	ID         int32                      `json:"id"`
+	SchemaType string                     `json:"schemaType,omitempty"`
+	Schema     string                     `json:"schema"`
+	References []srclient.SchemaReference `json:"references,omitempty"`
+	Metadata   *srclient.Metadata         `json:"metadata,omitempty"`
+	RuleSet    *srclient.RuleSet          `json:"ruleSet,omitempty"`
+	Deleted    bool                       `json:"deleted,omitempty"`
+}
+
+// VersionCount returns the total number of versions across all subjects
+func (a *Archive) VersionCount() int {
+	count := 0
+	for _, subject := range a.Subjects {
+		count += len(subject.Versions)
+	}
+
+	return count
+}
+
+// Encode serializes the archive as gzip compressed JSON
+func Encode(a *Archive) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(writer).Encode(a); err != nil {
+		return nil, fmt.Errorf("failed to encode archive: %w", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress archive: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Decode deserializes an archive written by Encode
+func Decode(data []byte) (*Archive, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress archive: %w", err)
+	}
+	defer reader.Close()
+
+	raw, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress archive: %w", err)
+	}
+
+	a := &Archive{}
+	if err = json.Unmarshal(raw, a); err != nil {
+		return nil, fmt.Errorf("failed to decode archive: %w", err)
+	}
+
+	if a.FormatVersion > FormatVersion {
+		return nil, fmt.Errorf("unsupported archive format version %d", a.FormatVersion)
+	}
+
+	return a, nil
+}
+
+// Checksum returns the SHA-256 checksum of the encoded archive
+func Checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// Split splits the encoded archive into chunks of at most size bytes
+func Split(data []byte, size int) [][]byte {
+	var chunks [][]byte
+	for len(data) > size {
+		chunks = append(chunks, data[:size])
+		data = data[size:]
+	}
+
+	return append(chunks, data)
+}
+
+// OrderByReferences returns the subjects ordered so each subject comes after the subjects its versions reference,
+// keeping the archive order otherwise. References to subjects outside the archive are ignored, and reference
+// cycles are broken by keeping the subject first reached in the archive order first.
+func OrderByReferences(subjects []Subject) []Subject {
+	indices := make(map[string]int, len(subjects))
+	for i, subject := range subjects {
+		indices[subject.Name] = i
+	}
+
+	ordered := make([]Subject, 0, len(subjects))
+	visited := make([]bool, len(subjects))
+
+	var visit func(i int)
+	visit = func(i int) {
+		if visited[i] {
+			return
+		}
+		visited[i] = true
+
+		for _, version := range subjects[i].Versions {
+			for _, reference := range version.References {
+				if reference.Subject == nil {
+					continue
+				}
+
+				if j, ok := indices[*reference.Subject]; ok {
+					visit(j)
+				}
+			}
+		}
+
+		ordered = append(ordered, subjects[i])
+	}
+
+	for i := range subjects {
+		visit(i)
+	}
+
+	return ordered
+}
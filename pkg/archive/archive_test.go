@@ -0,0 +1,199 @@
+package archive
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/utils/ptr"
+
+	"github.com/steffen-karlsson/schema-registry-operator/pkg/srclient"
+)
+
+func newArchive() *Archive {
+	return &Archive{
+		FormatVersion: FormatVersion,
+		CreatedAt:     time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		Registry:      "registry",
+		Config:        &srclient.Config{CompatibilityLevel: ptr.To(srclient.ConfigCompatibilityLevel("BACKWARD"))},
+		Mode:          "READWRITE",
+		Subjects: []Subject{
+			{
+				Name: "customer",
+				Versions: []Version{
+					{Version: 1, ID: 1, SchemaType: "AVRO", Schema: `{"type":"record","name":"Customer","fields":[]}`},
+				},
+			},
+			{
+				Name:    "order",
+				Deleted: true,
+				Mode:    "READONLY",
+				Versions: []Version{
+					{
+						Version: 1,
+						ID:      2,
+						Schema:  `{"type":"record","name":"Order","fields":[]}`,
+						References: []srclient.SchemaReference{
+							{Name: ptr.To("Customer"), Subject: ptr.To("customer"), Version: ptr.To(int32(1))},
+						},
+						Deleted: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	a := newArchive()
+
+	data, err := Encode(a)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	decoded, err := Decode(data)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(decoded, a) {
+		t.Errorf("Decode(Encode()) = %+v, want %+v", decoded, a)
+	}
+
+	if decoded.VersionCount() != 2 {
+		t.Errorf("VersionCount() = %d, want 2", decoded.VersionCount())
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	if _, err := Decode([]byte("not gzip")); err == nil {
+		t.Error("Decode() of data which is not compressed should fail")
+	}
+
+	future := newArchive()
+	future.FormatVersion = FormatVersion + 1
+	data, err := Encode(future)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	if _, err = Decode(data); err == nil || !strings.Contains(err.Error(), "unsupported archive format version") {
+		t.Errorf("Decode() of a newer format version error = %v, want unsupported archive format version", err)
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	data, err := Encode(newArchive())
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	checksum := Checksum(data)
+	if !strings.HasPrefix(checksum, "sha256:") || len(checksum) != len("sha256:")+64 {
+		t.Errorf("Checksum() = %q, want a sha256 checksum", checksum)
+	}
+
+	if Checksum(append(data, 0)) == checksum {
+		t.Error("Checksum() of different data should differ")
+	}
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   []byte
+		size   int
+		chunks []int
+	}{
+		{name: "empty", data: []byte{}, size: 4, chunks: []int{0}},
+		{name: "smaller than size", data: []byte("abc"), size: 4, chunks: []int{3}},
+		{name: "exactly size", data: []byte("abcd"), size: 4, chunks: []int{4}},
+		{name: "multiple of size", data: []byte("abcdefgh"), size: 4, chunks: []int{4, 4}},
+		{name: "remainder", data: []byte("abcdefghij"), size: 4, chunks: []int{4, 4, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunks := Split(tt.data, tt.size)
+			if len(chunks) != len(tt.chunks) {
+				t.Fatalf("Split() returned %d chunks, want %d", len(chunks), len(tt.chunks))
+			}
+
+			for i, chunk := range chunks {
+				if len(chunk) != tt.chunks[i] {
+					t.Errorf("Split() chunk %d has %d bytes, want %d", i, len(chunk), tt.chunks[i])
+				}
+			}
+
+			if joined := bytes.Join(chunks, nil); !bytes.Equal(joined, tt.data) {
+				t.Errorf("Split() chunks join to %q, want %q", joined, tt.data)
+			}
+		})
+	}
+}
+
+func TestOrderByReferences(t *testing.T) {
+	reference := func(subject string) []srclient.SchemaReference {
+		return []srclient.SchemaReference{{Name: ptr.To(subject), Subject: ptr.To(subject), Version: ptr.To(int32(1))}}
+	}
+
+	names := func(subjects []Subject) []string {
+		var result []string
+		for _, subject := range subjects {
+			result = append(result, subject.Name)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		subjects []Subject
+		want     []string
+	}{
+		{
+			name: "without references",
+			subjects: []Subject{
+				{Name: "a"},
+				{Name: "b"},
+			},
+			want: []string{"a", "b"},
+		},
+		{
+			name: "referenced subject after referencing subject",
+			subjects: []Subject{
+				{Name: "a-order", Versions: []Version{{Version: 1}, {Version: 2, References: reference("b-line")}}},
+				{Name: "b-line", Versions: []Version{{Version: 1, References: reference("c-customer")}}},
+				{Name: "c-customer", Versions: []Version{{Version: 1}}},
+				{Name: "d-invoice", Versions: []Version{{Version: 1, References: reference("c-customer")}}},
+			},
+			want: []string{"c-customer", "b-line", "a-order", "d-invoice"},
+		},
+		{
+			name: "reference outside the archive",
+			subjects: []Subject{
+				{Name: "a", Versions: []Version{{Version: 1, References: reference("external")}}},
+				{Name: "b"},
+			},
+			want: []string{"a", "b"},
+		},
+		{
+			name: "reference cycle",
+			subjects: []Subject{
+				{Name: "a", Versions: []Version{{Version: 1, References: reference("b")}}},
+				{Name: "b", Versions: []Version{{Version: 1, References: reference("a")}}},
+			},
+			want: []string{"b", "a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := names(OrderByReferences(tt.subjects)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("OrderByReferences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,21 +2,70 @@ package k8s_manager
 
 import (
 	"context"
+	"encoding/json"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 )
 
+// FieldManager is the field manager of the objects applied by the operator
+const FieldManager = "schema-registry-operator"
+
 type Client struct {
 	client.Client
 }
 
-// Upsert creates or updates the given object in the cluster
-func (r *Client) Upsert(ctx context.Context, obj client.Object, exists bool) error {
-	if exists {
-		return r.Update(ctx, obj)
+// Apply creates or updates the given object in the cluster with server-side apply. Only the fields set on the
+// object are owned by the operator, so drift in those fields is corrected while fields set by others are kept.
+func (r *Client) Apply(ctx context.Context, obj client.Object) error {
+	return r.ApplyAs(ctx, obj, FieldManager)
+}
+
+// ApplyAs applies the given object with server-side apply under the given field manager
+func (r *Client) ApplyAs(ctx context.Context, obj client.Object, fieldManager string) error {
+	gvk, err := apiutil.GVKForObject(obj, r.Scheme())
+	if err != nil {
+		return err
+	}
+
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	obj.SetManagedFields(nil)
+	obj.SetResourceVersion("")
+
+	return r.Patch(ctx, obj, client.Apply, client.FieldOwner(fieldManager), client.ForceOwnership)
+}
+
+// IsFieldAppliedBy checks if the field at the given path of the object, such as "spec", "replicas", is owned by
+// the field manager through server-side apply
+func IsFieldAppliedBy(obj client.Object, fieldManager string, path ...string) bool {
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Manager != fieldManager || entry.Operation != metav1.ManagedFieldsOperationApply || entry.FieldsV1 == nil {
+			continue
+		}
+
+		var fields map[string]any
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+			continue
+		}
+
+		for i, name := range path {
+			field, ok := fields["f:"+name]
+			if !ok {
+				break
+			}
+
+			if i == len(path)-1 {
+				return true
+			}
+
+			if fields, ok = field.(map[string]any); !ok {
+				break
+			}
+		}
 	}
 
-	return r.Create(ctx, obj)
+	return false
 }
 
 func NewClient(client client.Client) *Client {
@@ -0,0 +1,50 @@
+package k8s_manager
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestIsFieldAppliedBy(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			ManagedFields: []metav1.ManagedFieldsEntry{
+				{
+					Manager:   FieldManager,
+					Operation: metav1.ManagedFieldsOperationApply,
+					FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{},"f:template":{}}}`)},
+				},
+				{
+					Manager:   "kube-controller-manager",
+					Operation: metav1.ManagedFieldsOperationUpdate,
+					FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:status":{"f:replicas":{}}}`)},
+				},
+			},
+		},
+		Spec: appsv1.DeploymentSpec{Replicas: ptr.To(int32(3))},
+	}
+
+	tests := []struct {
+		name    string
+		manager string
+		path    []string
+		want    bool
+	}{
+		{name: "applied", manager: FieldManager, path: []string{"spec", "replicas"}, want: true},
+		{name: "parent", manager: FieldManager, path: []string{"spec"}, want: true},
+		{name: "not applied", manager: FieldManager, path: []string{"spec", "strategy"}},
+		{name: "other manager", manager: "other", path: []string{"spec", "replicas"}},
+		{name: "updated", manager: "kube-controller-manager", path: []string{"status", "replicas"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsFieldAppliedBy(deployment, tt.manager, tt.path...); got != tt.want {
+				t.Errorf("IsFieldAppliedBy(%s, %v) = %t, want %t", tt.manager, tt.path, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package k8s_manager
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// PodLogReader reads the logs of a container of a pod, which the controller-runtime client cannot read
+type PodLogReader interface {
+	GetLogs(ctx context.Context, namespace, name, container string) ([]byte, error)
+}
+
+type podLogReader struct {
+	clientset kubernetes.Interface
+}
+
+// GetLogs returns the complete logs of the container
+func (r *podLogReader) GetLogs(ctx context.Context, namespace, name, container string) ([]byte, error) {
+	return r.clientset.CoreV1().Pods(namespace).GetLogs(name, &corev1.PodLogOptions{Container: container}).DoRaw(ctx)
+}
+
+func NewPodLogReader(config *rest.Config) (PodLogReader, error) {
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &podLogReader{clientset: clientset}, nil
+}
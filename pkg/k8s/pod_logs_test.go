@@ -0,0 +1,26 @@
+package k8s_manager
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetLogs(t *testing.T) {
+	reader := &podLogReader{clientset: fake.NewSimpleClientset(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "reader", Namespace: "default"},
+	})}
+
+	// The fake clientset returns the same logs for every container
+	logs, err := reader.GetLogs(context.Background(), "default", "reader", "archive-reader")
+	if err != nil {
+		t.Fatalf("GetLogs() error = %v", err)
+	}
+
+	if string(logs) != "fake logs" {
+		t.Errorf("GetLogs() = %q, want %q", logs, "fake logs")
+	}
+}